@@ -0,0 +1,106 @@
+package alert
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"go.uber.org/zap/zapcore"
+	"time"
+)
+
+// Alert is the view of an alert entry passed to webhook templates.
+type Alert struct {
+	Fingerprint string         `json:"fingerprint"`      // Fingerprint groups repeated alerts for deduplication.
+	LogID       string         `json:"log_id,omitempty"` // LogID is the log_id of the entry when log IDs are enabled.
+	Time        time.Time      `json:"time"`
+	Level       string         `json:"level"` // Level is the upper case level, e.g. "ERROR".
+	Message     string         `json:"message"`
+	Severity    string         `json:"severity,omitempty"` // Severity, Team and RunbookURL come from the alert_info of the entry.
+	Team        string         `json:"team,omitempty"`
+	RunbookURL  string         `json:"runbook_url,omitempty"`
+	AppName     string         `json:"app_name"`
+	Version     string         `json:"version"`
+	LogType     string         `json:"log_type"`
+	Caller      string         `json:"caller,omitempty"`
+	Error       string         `json:"error,omitempty"`
+	TraceID     string         `json:"trace_id,omitempty"`
+	Data        map[string]any `json:"data,omitempty"`       // Data holds the remaining data of the entry.
+	Suppressed  int            `json:"suppressed,omitempty"` // Suppressed is the number of duplicates dropped since the previous delivery.
+}
+
+// newAlert builds an Alert from a zap entry and its fields, ok is false when the entry is not an alert.
+func newAlert(ent zapcore.Entry, fields []zapcore.Field) (a Alert, ok bool, err error) {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	if alert, _ := enc.Fields["alert"].(int64); alert != 1 {
+		return Alert{}, false, nil
+	}
+
+	b, err := json.Marshal(enc.Fields)
+	if err != nil {
+		return Alert{}, false, err
+	}
+
+	values := map[string]any{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&values); err != nil {
+		return Alert{}, false, err
+	}
+
+	a = Alert{
+		Time:    ent.Time,
+		Level:   ent.Level.CapitalString(),
+		Message: ent.Message,
+	}
+	a.LogID, _ = values["log_id"].(string)
+	a.AppName, _ = values["app_name"].(string)
+	a.Version, _ = values["version"].(string)
+	a.LogType, _ = values["log_type"].(string)
+
+	if info, ok := values["alert_info"].(map[string]any); ok {
+		a.Severity, _ = info["severity"].(string)
+		a.Team, _ = info["team"].(string)
+		a.RunbookURL, _ = info["runbook_url"].(string)
+	}
+
+	if ent.Caller.Defined {
+		a.Caller = ent.Caller.TrimmedPath()
+	}
+
+	if data, ok := values["data"].(map[string]any); ok {
+		a.Error, _ = data["error"].(string)
+		if tracing, ok := data["tracing"].(map[string]any); ok {
+			a.TraceID, _ = tracing["trace_id"].(string)
+		}
+		a.Fingerprint, _ = data["fingerprint"].(string)
+
+		delete(data, "error")
+		delete(data, "tracing")
+		delete(data, "stack_trace")
+		delete(data, "fingerprint")
+		if len(data) > 0 {
+			a.Data = data
+		}
+	}
+
+	if a.Fingerprint == "" {
+		a.Fingerprint = fingerprint(a)
+	}
+
+	return a, true, nil
+}
+
+// fingerprint hashes the fields identifying where an alert comes from, ignoring its data.
+func fingerprint(a Alert) string {
+	h := sha1.New()
+	for _, s := range []string{a.AppName, a.LogType, a.Level, a.Message, a.Caller} {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
@@ -0,0 +1,309 @@
+package alert
+
+import (
+	"fmt"
+	"go.uber.org/zap/zapcore"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Options configures a Dispatcher, zero values fall back to the defaults documented on each field.
+type Options struct {
+	Webhooks     []Webhook     // Webhooks receive every alert that passes deduplication and rate limiting.
+	DedupWindow  time.Duration // DedupWindow drops alerts with the same fingerprint within the window, 5m by default.
+	RateLimit    int           // RateLimit is the max number of alerts delivered per RateInterval, 10 by default.
+	RateInterval time.Duration // RateInterval is the window of RateLimit, 1m by default.
+	QueueSize    int           // QueueSize is the number of alerts waiting for delivery before new ones are dropped, 100 by default.
+	MaxRetries   int           // MaxRetries is the number of retries of a failed delivery, 3 by default, negative disables retries.
+	RetryBackoff time.Duration // RetryBackoff is the delay before the first retry, doubled on each retry, 1s by default.
+	Client       *http.Client  // Client sends the webhook requests, a client with a 10s timeout by default.
+	OnDrop       func(a Alert) // OnDrop is called for every alert dropped over the rate limit or queue size, e.g. to count it.
+}
+
+// Dispatcher delivers alert entries to webhooks asynchronously.
+// Use Core to plug it into a zap logger, Sync waits for queued alerts and Close stops the dispatcher.
+type Dispatcher struct {
+	opts     Options
+	webhooks []webhook
+	now      func() time.Time
+
+	mu          sync.Mutex
+	seen        map[string]*seenAlert
+	windowStart time.Time
+	windowCount int
+	failed      int
+	lastErr     error
+	inflight    int
+	idle        *sync.Cond
+
+	queue     chan Alert
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+type seenAlert struct {
+	alert      Alert
+	last       time.Time
+	suppressed int
+}
+
+// NewDispatcher validates the webhooks and starts the delivery loop.
+func NewDispatcher(opts Options) (*Dispatcher, error) {
+	if opts.DedupWindow <= 0 {
+		opts.DedupWindow = 5 * time.Minute
+	}
+	if opts.RateLimit <= 0 {
+		opts.RateLimit = 10
+	}
+	if opts.RateInterval <= 0 {
+		opts.RateInterval = time.Minute
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 100
+	}
+	if opts.MaxRetries < 0 {
+		opts.MaxRetries = 0
+	} else if opts.MaxRetries == 0 {
+		opts.MaxRetries = 3
+	}
+	if opts.RetryBackoff <= 0 {
+		opts.RetryBackoff = time.Second
+	}
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	d := &Dispatcher{
+		opts:  opts,
+		now:   time.Now,
+		seen:  map[string]*seenAlert{},
+		queue: make(chan Alert, opts.QueueSize),
+	}
+	d.idle = sync.NewCond(&d.mu)
+
+	for _, w := range opts.Webhooks {
+		wh, err := newWebhook(w)
+		if err != nil {
+			return nil, err
+		}
+		d.webhooks = append(d.webhooks, wh)
+	}
+
+	d.wg.Add(1)
+	go d.loop()
+
+	return d, nil
+}
+
+// Core returns a zapcore.Core dispatching the alert entries enabled by enab, other entries are ignored.
+func (d *Dispatcher) Core(enab zapcore.LevelEnabler) zapcore.Core {
+	return &core{LevelEnabler: enab, dispatcher: d}
+}
+
+// Dispatch queues an alert unless it is a duplicate or over the rate limit, it reports whether it was queued.
+// Alerts dropped over the rate limit or queue size are reported to Options.OnDrop.
+func (d *Dispatcher) Dispatch(a Alert) bool {
+	queued, dropped := d.dispatch(a)
+	if d.opts.OnDrop != nil {
+		for _, a := range dropped {
+			d.opts.OnDrop(a)
+		}
+	}
+	return queued
+}
+
+func (d *Dispatcher) dispatch(a Alert) (queued bool, dropped []Alert) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+
+	s, ok := d.seen[a.Fingerprint]
+	if ok && now.Sub(s.last) < d.opts.DedupWindow {
+		s.suppressed++
+		return false, nil
+	}
+
+	if now.Sub(d.windowStart) >= d.opts.RateInterval {
+		d.windowStart = now
+		d.windowCount = 0
+	}
+	if d.windowCount >= d.opts.RateLimit {
+		return false, []Alert{a}
+	}
+
+	if ok {
+		a.Suppressed = s.suppressed
+	}
+
+	if !d.enqueue(a) {
+		return false, []Alert{a}
+	}
+
+	d.windowCount++
+	d.seen[a.Fingerprint] = &seenAlert{alert: a, last: now}
+
+	return true, d.prune(now)
+}
+
+// enqueue queues a without blocking and reports whether there was room, it is called with mu held.
+func (d *Dispatcher) enqueue(a Alert) bool {
+	select {
+	case d.queue <- a:
+		d.inflight++
+		return true
+	default:
+		return false
+	}
+}
+
+// prune forgets fingerprints outside of the dedup window, it is called with mu held.
+// Fingerprints with suppressed duplicates are flushed first with a summary, a copy of the last delivered alert
+// carrying their number in Suppressed. It returns the summaries dropped over the queue size.
+func (d *Dispatcher) prune(now time.Time) (dropped []Alert) {
+	for fp, s := range d.seen {
+		if now.Sub(s.last) < d.opts.DedupWindow {
+			continue
+		}
+		if s.suppressed > 0 {
+			summary := s.alert
+			summary.Suppressed = s.suppressed
+			if !d.enqueue(summary) {
+				dropped = append(dropped, summary)
+			}
+		}
+		delete(d.seen, fp)
+	}
+	return dropped
+}
+
+// Sync waits for the queued alerts to be delivered and reports the deliveries failed since the last call.
+// Duplicates and alerts over the rate limit are expected and not reported, see Options.OnDrop.
+func (d *Dispatcher) Sync() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for d.inflight > 0 {
+		d.idle.Wait()
+	}
+
+	failed, lastErr := d.failed, d.lastErr
+	d.failed, d.lastErr = 0, nil
+
+	if failed > 0 {
+		return fmt.Errorf("alert: failed to deliver %d alerts: %w", failed, lastErr)
+	}
+	return nil
+}
+
+// Close delivers the queued alerts and stops the delivery loop, alerts dispatched after Close are dropped.
+func (d *Dispatcher) Close() error {
+	err := d.Sync()
+
+	d.closeOnce.Do(func() {
+		d.mu.Lock()
+		close(d.queue)
+		d.queue = nil
+		d.mu.Unlock()
+	})
+	d.wg.Wait()
+
+	return err
+}
+
+func (d *Dispatcher) loop() {
+	defer d.wg.Done()
+
+	d.mu.Lock()
+	queue := d.queue
+	d.mu.Unlock()
+
+	for a := range queue {
+		var errs []error
+		for _, w := range d.webhooks {
+			if !w.matches(a) {
+				continue
+			}
+			if err := d.deliver(w, a); err != nil {
+				errs = append(errs, fmt.Errorf("%s %s: %w", w.Kind, w.URL, err))
+			}
+		}
+
+		d.mu.Lock()
+		for _, err := range errs {
+			d.failed++
+			d.lastErr = err
+		}
+		d.inflight--
+		if d.inflight == 0 {
+			d.idle.Broadcast()
+		}
+		d.mu.Unlock()
+	}
+}
+
+func (d *Dispatcher) deliver(w webhook, a Alert) error {
+	backoff := d.opts.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		req, err := w.request(a)
+		if err != nil {
+			return err
+		}
+
+		retryable, err := send(d.opts.Client, req)
+		if err == nil || !retryable || attempt >= d.opts.MaxRetries {
+			return err
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// core adapts the dispatcher to zapcore.Core, fields added with With are kept per core.
+type core struct {
+	zapcore.LevelEnabler
+	dispatcher *Dispatcher
+	fields     []zapcore.Field
+}
+
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	return &core{
+		LevelEnabler: c.LevelEnabler,
+		dispatcher:   c.dispatcher,
+		fields:       append(c.fields[:len(c.fields):len(c.fields)], fields...),
+	}
+}
+
+func (c *core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	all := fields
+	if len(c.fields) > 0 {
+		all = append(c.fields[:len(c.fields):len(c.fields)], fields...)
+	}
+
+	a, ok, err := newAlert(ent, all)
+	if err != nil || !ok {
+		return err
+	}
+
+	c.dispatcher.Dispatch(a)
+
+	// Like the zap io core, deliver panic and fatal alerts before the process panics or exits.
+	if ent.Level > zapcore.ErrorLevel {
+		_ = c.dispatcher.Sync()
+	}
+
+	return nil
+}
+
+func (c *core) Sync() error {
+	return c.dispatcher.Sync()
+}
@@ -0,0 +1,294 @@
+package alert
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+type request struct {
+	header http.Header
+	body   string
+}
+
+// receiver is a local stand-in for the webhook endpoints.
+type receiver struct {
+	mu       sync.Mutex
+	failures int
+	requests []request
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.failures > 0 {
+		r.failures--
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	body, _ := io.ReadAll(req.Body)
+	r.requests = append(r.requests, request{header: req.Header.Clone(), body: string(body)})
+}
+
+func newLogger(d *Dispatcher) *zap.Logger {
+	return zap.New(d.Core(zapcore.DebugLevel), zap.AddCaller()).
+		With(zap.String("app_name", "order-service"), zap.String("version", "v1.2.3"))
+}
+
+func alertFields(data map[string]any) []zap.Field {
+	return []zap.Field{zap.Int("alert", 1), zap.String("log_type", "application"), zap.Any("data", data)}
+}
+
+func TestDispatcher_Webhooks(t *testing.T) {
+	r := &receiver{}
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	d, err := NewDispatcher(Options{Webhooks: []Webhook{
+		{Kind: KindSlack, URL: srv.URL + "/slack"},
+		{Kind: KindLINENotify, URL: srv.URL + "/line", Token: "line-token", Template: "{{.AppName}}: {{.Message}}"},
+		{URL: srv.URL + "/json", Headers: map[string]string{"X-Api-Key": "key"}},
+	}})
+	assert.NoError(t, err)
+	defer d.Close()
+
+	logger := newLogger(d)
+	logger.Error("not an alert", zap.Int("alert", 0))
+	logger.Error("Payment failed", append(alertFields(map[string]any{
+		"error":   "card declined",
+		"tracing": map[string]string{"trace_id": "0af7651916cd43dd8448eb211c80319c", "span_id": "b7ad6b7169203331"},
+		"order":   "ORD_1",
+	}), zap.String("log_id", "01HESGV6AKX5QZ8R9T3W2Y4N6M"))...)
+
+	assert.NoError(t, d.Sync())
+	assert.Len(t, r.requests, 3)
+
+	slack := map[string]any{}
+	assert.NoError(t, json.Unmarshal([]byte(r.requests[0].body), &slack))
+	assert.Regexp(t, `^\[ERROR\] order-service v1.2.3: Payment failed
+error: card declined
+trace_id: 0af7651916cd43dd8448eb211c80319c
+caller: alert/dispatcher_test.go:\d+$`, slack["text"])
+
+	line, _ := url.ParseQuery(r.requests[1].body)
+	assert.Equal(t, "order-service: Payment failed", line.Get("message"))
+	assert.Equal(t, "Bearer line-token", r.requests[1].header.Get("Authorization"))
+	assert.Equal(t, "application/x-www-form-urlencoded", r.requests[1].header.Get("Content-Type"))
+
+	generic := struct {
+		Text  string `json:"text"`
+		Alert Alert  `json:"alert"`
+	}{}
+	assert.NoError(t, json.Unmarshal([]byte(r.requests[2].body), &generic))
+	assert.Equal(t, "key", r.requests[2].header.Get("X-Api-Key"))
+	assert.Equal(t, slack["text"], generic.Text)
+	assert.Equal(t, "Payment failed", generic.Alert.Message)
+	assert.Equal(t, "application", generic.Alert.LogType)
+	assert.Equal(t, "01HESGV6AKX5QZ8R9T3W2Y4N6M", generic.Alert.LogID)
+	assert.Equal(t, map[string]any{"order": "ORD_1"}, generic.Alert.Data)
+	assert.Len(t, generic.Alert.Fingerprint, 16)
+}
+
+func TestDispatcher_Dedup(t *testing.T) {
+	r := &receiver{}
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	d, err := NewDispatcher(Options{Webhooks: []Webhook{{URL: srv.URL}}, DedupWindow: time.Minute})
+	assert.NoError(t, err)
+	defer d.Close()
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	d.now = func() time.Time { return now }
+
+	assert.True(t, d.Dispatch(Alert{Fingerprint: "a", Message: "first"}))
+	assert.False(t, d.Dispatch(Alert{Fingerprint: "a", Message: "duplicate"}))
+	assert.False(t, d.Dispatch(Alert{Fingerprint: "a", Message: "duplicate"}))
+	assert.True(t, d.Dispatch(Alert{Fingerprint: "b", Message: "other"}))
+
+	now = now.Add(time.Minute)
+	assert.True(t, d.Dispatch(Alert{Fingerprint: "a", Message: "again"}))
+
+	assert.NoError(t, d.Sync())
+	assert.Len(t, r.requests, 3)
+
+	last := struct {
+		Alert Alert `json:"alert"`
+	}{}
+	assert.NoError(t, json.Unmarshal([]byte(r.requests[2].body), &last))
+	assert.Equal(t, "again", last.Alert.Message)
+	assert.Equal(t, 2, last.Alert.Suppressed)
+}
+
+func TestDispatcher_DedupSummary(t *testing.T) {
+	r := &receiver{}
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	d, err := NewDispatcher(Options{Webhooks: []Webhook{{URL: srv.URL}}, DedupWindow: time.Minute})
+	assert.NoError(t, err)
+	defer d.Close()
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	d.now = func() time.Time { return now }
+
+	assert.True(t, d.Dispatch(Alert{Fingerprint: "a", Message: "first"}))
+	assert.False(t, d.Dispatch(Alert{Fingerprint: "a", Message: "duplicate"}))
+	assert.False(t, d.Dispatch(Alert{Fingerprint: "a", Message: "duplicate"}))
+
+	// "a" never fires again, its duplicates are flushed once the window is over.
+	now = now.Add(time.Minute)
+	assert.True(t, d.Dispatch(Alert{Fingerprint: "b", Message: "other"}))
+
+	assert.NoError(t, d.Sync())
+	assert.Len(t, r.requests, 3)
+	assert.NotContains(t, d.seen, "a")
+
+	var messages []string
+	suppressed := map[string]int{}
+	for _, req := range r.requests {
+		body := struct {
+			Alert Alert `json:"alert"`
+		}{}
+		assert.NoError(t, json.Unmarshal([]byte(req.body), &body))
+		messages = append(messages, body.Alert.Message)
+		suppressed[body.Alert.Message] += body.Alert.Suppressed
+	}
+	assert.ElementsMatch(t, []string{"first", "first", "other"}, messages)
+	assert.Equal(t, map[string]int{"first": 2, "other": 0}, suppressed)
+}
+
+func TestDispatcher_RateLimit(t *testing.T) {
+	r := &receiver{}
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	var dropped []string
+	d, err := NewDispatcher(Options{
+		Webhooks:     []Webhook{{URL: srv.URL}},
+		RateLimit:    2,
+		RateInterval: time.Minute,
+		OnDrop:       func(a Alert) { dropped = append(dropped, a.Fingerprint) },
+	})
+	assert.NoError(t, err)
+	defer d.Close()
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	d.now = func() time.Time { return now }
+
+	assert.True(t, d.Dispatch(Alert{Fingerprint: "a"}))
+	assert.True(t, d.Dispatch(Alert{Fingerprint: "b"}))
+	assert.False(t, d.Dispatch(Alert{Fingerprint: "c"}))
+	assert.False(t, d.Dispatch(Alert{Fingerprint: "a"}))
+
+	// Drops over the rate limit are counted through OnDrop, duplicates are not drops and neither is an error.
+	assert.NoError(t, d.Sync())
+	assert.Equal(t, []string{"c"}, dropped)
+
+	now = now.Add(time.Minute)
+	assert.True(t, d.Dispatch(Alert{Fingerprint: "c"}))
+
+	assert.NoError(t, d.Sync())
+	assert.Len(t, r.requests, 3)
+}
+
+func TestCore_FlushesPanic(t *testing.T) {
+	r := &receiver{}
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	d, err := NewDispatcher(Options{Webhooks: []Webhook{{URL: srv.URL}}})
+	assert.NoError(t, err)
+	defer d.Close()
+
+	// The alert is delivered before the logger panics, without a call to Sync.
+	assert.Panics(t, func() { newLogger(d).Panic("Out of memory", alertFields(nil)...) })
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	assert.Len(t, r.requests, 1)
+}
+
+func TestDispatcher_Retry(t *testing.T) {
+	r := &receiver{failures: 2}
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	d, err := NewDispatcher(Options{Webhooks: []Webhook{{URL: srv.URL}}, RetryBackoff: time.Millisecond})
+	assert.NoError(t, err)
+	defer d.Close()
+
+	assert.True(t, d.Dispatch(Alert{Fingerprint: "a"}))
+	assert.NoError(t, d.Sync())
+	assert.Len(t, r.requests, 1)
+
+	r.failures = 10
+	assert.True(t, d.Dispatch(Alert{Fingerprint: "b"}))
+	assert.EqualError(t, d.Sync(), "alert: failed to deliver 1 alerts: json "+srv.URL+": 500 Internal Server Error")
+	assert.Equal(t, 6, r.failures)
+}
+
+func TestNewDispatcher_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		webhook Webhook
+		err     string
+	}{
+		{name: "Kind", webhook: Webhook{Kind: "teams", URL: "http://localhost"}, err: `alert: unknown webhook kind "teams"`},
+		{name: "URL", webhook: Webhook{Kind: KindSlack}, err: "alert: slack webhook URL is required"},
+		{name: "Template", webhook: Webhook{URL: "http://localhost", Template: "{{.Message"}, err: "alert: invalid json webhook template: template: json:1: unclosed action"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewDispatcher(Options{Webhooks: []Webhook{tt.webhook}})
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestDispatcher_Routing(t *testing.T) {
+	r := &receiver{}
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	d, err := NewDispatcher(Options{Webhooks: []Webhook{
+		{Kind: KindSlack, URL: srv.URL + "/payments", Teams: []string{"payments"}},
+		{Kind: KindSlack, URL: srv.URL + "/oncall", Severities: []string{"p1", "critical"}},
+	}})
+	assert.NoError(t, err)
+	defer d.Close()
+
+	logger := newLogger(d)
+	logger.Error("Payment failed", append(alertFields(nil),
+		zap.Any("alert_info", map[string]string{"severity": "p1", "team": "payments", "runbook_url": "https://runbook/payments"}))...)
+	logger.Warn("Refund slow", append(alertFields(nil), zap.Any("alert_info", map[string]string{"severity": "p3", "team": "payments"}))...)
+	logger.Warn("Stock low", append(alertFields(nil), zap.Any("alert_info", map[string]string{"severity": "p3", "team": "inventory"}))...)
+
+	assert.NoError(t, d.Sync())
+
+	var texts []string
+	for _, req := range r.requests {
+		body := map[string]string{}
+		assert.NoError(t, json.Unmarshal([]byte(req.body), &body))
+		texts = append(texts, body["text"])
+	}
+
+	assert.Len(t, texts, 3)
+	assert.Regexp(t, `^\[ERROR p1\] order-service v1.2.3: Payment failed
+team: payments
+caller: .+
+runbook: https://runbook/payments$`, texts[0])
+	assert.Equal(t, texts[0], texts[1])
+	assert.Contains(t, texts[2], "[WARN p3] order-service v1.2.3: Refund slow")
+}
@@ -0,0 +1,163 @@
+package alert
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+)
+
+// Kind selects the payload format of a webhook.
+type Kind string
+
+const (
+	KindSlack      Kind = "slack"       // Slack incoming webhook, {"text": message}.
+	KindLINENotify Kind = "line_notify" // LINE Notify, form encoded message with a bearer token.
+	KindJSON       Kind = "json"        // Generic JSON, {"text": message, "alert": Alert}.
+)
+
+// LINENotifyURL is used for KindLINENotify webhooks without a URL.
+const LINENotifyURL = "https://notify-api.line.me/api/notify"
+
+// DefaultTemplate renders alerts of webhooks without a Template.
+const DefaultTemplate = `[{{.Level}}{{if .Severity}} {{.Severity}}{{end}}] {{.AppName}} {{.Version}}: {{.Message}}` +
+	`{{if .Team}}
+team: {{.Team}}{{end}}` +
+	`{{if .Error}}
+error: {{.Error}}{{end}}` +
+	`{{if .TraceID}}
+trace_id: {{.TraceID}}{{end}}` +
+	`{{if .Caller}}
+caller: {{.Caller}}{{end}}` +
+	`{{if .RunbookURL}}
+runbook: {{.RunbookURL}}{{end}}` +
+	`{{if .Suppressed}}
+({{.Suppressed}} similar alerts suppressed){{end}}`
+
+// Webhook is a destination alerts are posted to.
+type Webhook struct {
+	Kind     Kind              // Kind is the payload format, KindJSON by default.
+	URL      string            // URL receives the POST request.
+	Token    string            // Token is sent as a bearer token, required by LINE Notify.
+	Headers  map[string]string // Headers are added to every request.
+	Template string            // Template is a text/template executed with an Alert, DefaultTemplate by default.
+
+	// Teams and Severities route alerts to this webhook only when they match, an empty list matches every alert.
+	Teams      []string
+	Severities []string
+}
+
+type webhook struct {
+	Webhook
+	tmpl *template.Template
+}
+
+func newWebhook(w Webhook) (webhook, error) {
+	if w.Kind == "" {
+		w.Kind = KindJSON
+	}
+	if w.Kind == KindLINENotify && w.URL == "" {
+		w.URL = LINENotifyURL
+	}
+	if w.Template == "" {
+		w.Template = DefaultTemplate
+	}
+
+	switch w.Kind {
+	case KindSlack, KindLINENotify, KindJSON:
+	default:
+		return webhook{}, fmt.Errorf("alert: unknown webhook kind %q", w.Kind)
+	}
+
+	if w.URL == "" {
+		return webhook{}, fmt.Errorf("alert: %s webhook URL is required", w.Kind)
+	}
+
+	tmpl, err := template.New(string(w.Kind)).Parse(w.Template)
+	if err != nil {
+		return webhook{}, fmt.Errorf("alert: invalid %s webhook template: %w", w.Kind, err)
+	}
+
+	return webhook{Webhook: w, tmpl: tmpl}, nil
+}
+
+// matches reports whether the alert is routed to the webhook.
+func (w webhook) matches(a Alert) bool {
+	return contains(w.Teams, a.Team) && contains(w.Severities, a.Severity)
+}
+
+func contains(list []string, s string) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// request builds the POST request delivering a to the webhook.
+func (w webhook) request(a Alert) (*http.Request, error) {
+	var text strings.Builder
+	if err := w.tmpl.Execute(&text, a); err != nil {
+		return nil, err
+	}
+
+	var body []byte
+	contentType := "application/json"
+
+	switch w.Kind {
+	case KindSlack:
+		body, _ = json.Marshal(map[string]any{"text": text.String()})
+	case KindLINENotify:
+		body = []byte(url.Values{"message": {text.String()}}.Encode())
+		contentType = "application/x-www-form-urlencoded"
+	default:
+		b, err := json.Marshal(map[string]any{"text": text.String(), "alert": a})
+		if err != nil {
+			return nil, err
+		}
+		body = b
+	}
+
+	req, err := http.NewRequest(http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	if w.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+w.Token)
+	}
+	for k, v := range w.Headers {
+		req.Header.Set(k, v)
+	}
+
+	return req, nil
+}
+
+// send posts one request, it reports whether a failure is worth retrying.
+func send(client *http.Client, req *http.Request) (bool, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, errors.New(resp.Status)
+	default:
+		return false, errors.New(resp.Status)
+	}
+}
@@ -0,0 +1,142 @@
+// Command slog-schema prints the JSON schema of Sellsuki log lines and validates log streams against it.
+//
+//	slog-schema print [log_type...]
+//	kubectl logs deploy/order-service | slog-schema validate
+//	slog-schema validate app.log other.log
+//	slog-schema -schema custom.json validate app.log
+//
+// Only built-in types are known to the command, the schemas of custom types registered by a service are read
+// with -schema from a JSON object of log_type to schema, e.g. schema.All() encoded in the service.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/schema"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/zap_logger"
+	"io"
+	"os"
+)
+
+func main() {
+	schemaFile := flag.String("schema", "", "JSON file of extra log_type schemas to validate against, e.g. custom types")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: slog-schema print [log_type...]")
+		fmt.Fprintln(flag.CommandLine.Output(), "       slog-schema [-schema file] validate [file...]")
+	}
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	switch flag.Arg(0) {
+	case "print":
+		os.Exit(printSchemas(os.Stdout, flag.Args()[1:]))
+	case "validate":
+		v := schema.Validator{}
+		if *schemaFile != "" {
+			types, err := loadSchemas(*schemaFile)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			v.Types = types
+		}
+		os.Exit(validate(os.Stdout, os.Stderr, v, flag.Args()[1:]))
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+func printSchemas(w io.Writer, types []string) int {
+	out := map[zap_logger.Type]*schema.Schema{}
+
+	if len(types) == 0 {
+		out = schema.All()
+	}
+
+	for _, t := range types {
+		s, ok := schema.ForType(zap_logger.Type(t))
+		if !ok {
+			fmt.Fprintf(os.Stderr, "unknown log type %q\n", t)
+			return 1
+		}
+		out[zap_logger.Type(t)] = s
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	return 0
+}
+
+// loadSchemas reads a JSON object of log_type to whole log line schema, as printed by print.
+func loadSchemas(name string) (map[zap_logger.Type]*schema.Schema, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	types := map[zap_logger.Type]*schema.Schema{}
+	if err := json.Unmarshal(b, &types); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return types, nil
+}
+
+func validate(stdout io.Writer, stderr io.Writer, v schema.Validator, files []string) int {
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	var total, invalid int
+	for _, name := range files {
+		t, i, err := validateFile(stdout, v, name)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+
+		total += t
+		invalid += i
+	}
+
+	fmt.Fprintf(stderr, "%d lines checked, %d invalid\n", total, invalid)
+
+	if invalid > 0 {
+		return 1
+	}
+
+	return 0
+}
+
+// validateFile validates the lines of a file, or of stdin for "-", and closes the file before returning.
+func validateFile(stdout io.Writer, v schema.Validator, name string) (int, int, error) {
+	r := io.Reader(os.Stdin)
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return 0, 0, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	total, invalid, err := v.ValidateStream(r, func(lineNo int, err error) {
+		fmt.Fprintf(stdout, "%s:%d: %v\n", name, lineNo, err)
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return total, invalid, nil
+}
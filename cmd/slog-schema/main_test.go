@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/schema"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/zap_logger"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const validLine = `{"level":"info","timestamp":"2023-11-09T14:48:14.803+0700","caller":"main.go:44","message":"Info message","app_name":"sampleApp","version":"v1.0.0","alert":0,"log_type":"application","data":{}}`
+
+func writeFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestValidate(t *testing.T) {
+	valid := writeFile(t, "valid.log", validLine+"\n"+validLine+"\n")
+	invalid := writeFile(t, "invalid.log", validLine+"\n"+`{"level":"info","alert":2}`+"\n")
+
+	tests := []struct {
+		name       string
+		files      []string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			name:       "Valid",
+			files:      []string{valid},
+			wantCode:   0,
+			wantStderr: "2 lines checked, 0 invalid\n",
+		},
+		{
+			name:       "Invalid",
+			files:      []string{valid, invalid},
+			wantCode:   1,
+			wantStdout: invalid + ":2: ",
+			wantStderr: "4 lines checked, 1 invalid\n",
+		},
+		{
+			name:       "Missing file",
+			files:      []string{valid, filepath.Join(t.TempDir(), "missing.log")},
+			wantCode:   1,
+			wantStderr: "missing.log: no such file or directory\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			assert.Equal(t, tt.wantCode, validate(&stdout, &stderr, schema.Validator{}, tt.files))
+			if tt.wantStdout == "" {
+				assert.Empty(t, stdout.String())
+			} else {
+				assert.Contains(t, stdout.String(), tt.wantStdout)
+			}
+			assert.Contains(t, stderr.String(), tt.wantStderr)
+		})
+	}
+}
+
+func TestValidate_Schema(t *testing.T) {
+	type grpcPayload struct {
+		Method string `json:"method"`
+	}
+
+	// The schema file is written by a service registering the type, the command does not know it.
+	grpc := zap_logger.MustRegisterType(zap_logger.TypeDefinition{Name: "handler.grpc_cli", PayloadKey: "grpc", Payload: grpcPayload{}})
+	s, _ := schema.ForType(grpc)
+	zap_logger.UnregisterType(grpc)
+
+	b, err := json.Marshal(map[zap_logger.Type]*schema.Schema{grpc: s})
+	assert.NoError(t, err)
+	schemaFile := writeFile(t, "custom.json", string(b))
+
+	line := `{"level":"info","timestamp":"2023-11-09T14:48:14.803+0700","caller":"main.go:44","message":"GRPC","app_name":"sampleApp","version":"v1.0.0","alert":0,"log_type":"handler.grpc_cli","data":{"grpc":{"method":"/orders.Order/Get"}}}`
+	logFile := writeFile(t, "grpc.log", line+"\n"+`{"level":"info","timestamp":"2023-11-09T14:48:14.803+0700","caller":"main.go:44","message":"GRPC","app_name":"sampleApp","version":"v1.0.0","alert":0,"log_type":"handler.grpc_cli","data":{"grpc":{}}}`+"\n")
+
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 1, validate(&stdout, &stderr, schema.Validator{}, []string{logFile}))
+	assert.Contains(t, stderr.String(), "2 lines checked, 2 invalid")
+	assert.Contains(t, stdout.String(), `unknown log type "handler.grpc_cli"`)
+
+	types, err := loadSchemas(schemaFile)
+	assert.NoError(t, err)
+
+	stdout.Reset()
+	stderr.Reset()
+	assert.Equal(t, 1, validate(&stdout, &stderr, schema.Validator{Types: types}, []string{logFile}))
+	assert.Equal(t, logFile+":2: data.grpc.method: required property is missing\n", stdout.String())
+	assert.Contains(t, stderr.String(), "2 lines checked, 1 invalid")
+}
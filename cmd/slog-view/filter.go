@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var levelRank = map[string]int{
+	"debug":  -1,
+	"info":   0,
+	"warn":   1,
+	"error":  2,
+	"dpanic": 3,
+	"panic":  4,
+	"fatal":  5,
+}
+
+// timestampLayouts are the layouts tried when parsing the timestamp of an entry.
+var timestampLayouts = []string{
+	"2006-01-02T15:04:05.000Z0700",
+	time.RFC3339Nano,
+}
+
+// filter selects the entries to print, zero values match everything.
+type filter struct {
+	types    map[string]struct{}
+	minLevel *int
+	app      string
+	traceID  string
+	alert    bool
+	since    time.Time
+	until    time.Time
+}
+
+func (f filter) empty() bool {
+	return len(f.types) == 0 && f.minLevel == nil && f.app == "" && f.traceID == "" && !f.alert &&
+		f.since.IsZero() && f.until.IsZero()
+}
+
+func (f filter) match(entry map[string]any) bool {
+	if len(f.types) > 0 {
+		if _, ok := f.types[str(entry["log_type"])]; !ok {
+			return false
+		}
+	}
+
+	if f.minLevel != nil {
+		rank, ok := levelRank[str(entry["level"])]
+		if !ok || rank < *f.minLevel {
+			return false
+		}
+	}
+
+	if f.app != "" && str(entry["app_name"]) != f.app {
+		return false
+	}
+
+	if f.alert && str(entry["alert"]) != "1" {
+		return false
+	}
+
+	if f.traceID != "" {
+		data, _ := entry["data"].(map[string]any)
+		tracing, _ := data["tracing"].(map[string]any)
+		if str(tracing["trace_id"]) != f.traceID {
+			return false
+		}
+	}
+
+	if !f.since.IsZero() || !f.until.IsZero() {
+		ts, ok := parseTimestamp(entry["timestamp"])
+		if !ok || (!f.since.IsZero() && ts.Before(f.since)) || (!f.until.IsZero() && ts.After(f.until)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func parseTimestamp(v any) (time.Time, bool) {
+	s := str(v)
+
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.UnixMilli(ms), true
+	}
+
+	return time.Time{}, false
+}
+
+// parseTimeFlag accepts an RFC3339 time or a duration relative to now, e.g. "15m" means 15 minutes ago.
+func parseTimeFlag(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q, use RFC3339 or a duration such as 15m", s)
+	}
+
+	return t, nil
+}
+
+func parseTypes(s string) map[string]struct{} {
+	if s == "" {
+		return nil
+	}
+
+	types := map[string]struct{}{}
+	for _, t := range strings.Split(s, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types[t] = struct{}{}
+		}
+	}
+
+	return types
+}
+
+func str(v any) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
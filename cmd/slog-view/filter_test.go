@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestFilter_Match(t *testing.T) {
+	var entry map[string]any
+	_ = json.Unmarshal([]byte(`{"level":"warn","timestamp":"2023-11-09T14:48:14.803+0700","app_name":"order","alert":1,"log_type":"handler.http","data":{"tracing":{"trace_id":"abc","span_id":"def"}}}`), &entry)
+
+	warn, errorRank := levelRank["warn"], levelRank["error"]
+	at := time.Date(2023, 11, 9, 7, 48, 14, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		filter filter
+		want   bool
+	}{
+		{name: "Empty filter", filter: filter{}, want: true},
+		{name: "Matching type", filter: filter{types: parseTypes("audit, handler.http")}, want: true},
+		{name: "Other type", filter: filter{types: parseTypes("audit")}, want: false},
+		{name: "Minimum level reached", filter: filter{minLevel: &warn}, want: true},
+		{name: "Minimum level not reached", filter: filter{minLevel: &errorRank}, want: false},
+		{name: "Other app", filter: filter{app: "payment"}, want: false},
+		{name: "Matching trace", filter: filter{traceID: "abc", alert: true}, want: true},
+		{name: "Other trace", filter: filter{traceID: "xyz"}, want: false},
+		{name: "Inside time range", filter: filter{since: at, until: at.Add(time.Second)}, want: true},
+		{name: "Before time range", filter: filter{since: at.Add(time.Second)}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.match(entry))
+		})
+	}
+}
+
+func TestParseTimeFlag(t *testing.T) {
+	now := time.Date(2023, 11, 9, 12, 0, 0, 0, time.UTC)
+
+	got, err := parseTimeFlag("15m", now)
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(-15*time.Minute), got)
+
+	got, err = parseTimeFlag("2023-11-09T10:00:00Z", now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 11, 9, 10, 0, 0, 0, time.UTC), got)
+
+	_, err = parseTimeFlag("yesterday", now)
+	assert.Error(t, err)
+}
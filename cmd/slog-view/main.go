@@ -0,0 +1,172 @@
+// Command slog-view pretty-prints and filters Sellsuki JSON logs read from files or stdin.
+//
+//	kubectl logs -f deploy/order-service | slog-view -level warn -type handler.http,handler.kafka
+//	slog-view -f -trace 0102030405060708090a0b0c0d0e0f10 app.log
+//
+// Lines that are not JSON are printed unchanged unless a filter is set.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/pretty"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+func main() {
+	var (
+		types   = flag.String("type", "", "comma separated log types to show, e.g. handler.http,audit")
+		minLvl  = flag.String("level", "", "minimum level to show: debug, info, warn, error, panic or fatal")
+		app     = flag.String("app", "", "only show entries of this app_name")
+		traceID = flag.String("trace", "", "only show entries with this trace ID")
+		alert   = flag.Bool("alert", false, "only show entries with alert")
+		since   = flag.String("since", "", "only show entries at or after this RFC3339 time or duration ago, e.g. 15m")
+		until   = flag.String("until", "", "only show entries at or before this RFC3339 time or duration ago")
+		follow  = flag.Bool("f", false, "keep reading files as they grow")
+		noColor = flag.Bool("no-color", false, "disable colors, they are disabled automatically when stdout is not a terminal")
+	)
+	flag.Parse()
+
+	now := time.Now()
+	f := filter{types: parseTypes(*types), app: *app, traceID: *traceID, alert: *alert}
+
+	if *minLvl != "" {
+		rank, ok := levelRank[*minLvl]
+		if !ok {
+			fatalf("invalid level %q", *minLvl)
+		}
+		f.minLevel = &rank
+	}
+
+	var err error
+	if f.since, err = parseTimeFlag(*since, now); err != nil {
+		fatalf("%v", err)
+	}
+	if f.until, err = parseTimeFlag(*until, now); err != nil {
+		fatalf("%v", err)
+	}
+
+	v := &viewer{
+		out:    bufio.NewWriter(os.Stdout),
+		filter: f,
+		opts:   pretty.Options{Color: !*noColor && pretty.IsTerminal(os.Stdout)},
+	}
+
+	files := flag.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	var wg sync.WaitGroup
+	for _, name := range files {
+		r, err := open(name, *follow)
+		if err != nil {
+			fatalf("%v", err)
+		}
+
+		if !*follow {
+			v.read(r)
+			r.Close()
+			continue
+		}
+
+		wg.Add(1)
+		go func(r io.ReadCloser) {
+			defer wg.Done()
+			defer r.Close()
+			v.read(r)
+		}(r)
+	}
+	wg.Wait()
+}
+
+// open returns the reader of a file, or of stdin for "-" which is left open when the reader is closed.
+func open(name string, follow bool) (io.ReadCloser, error) {
+	if name == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if follow {
+		return &followReader{r: f, interval: 250 * time.Millisecond}, nil
+	}
+
+	return f, nil
+}
+
+type viewer struct {
+	mu     sync.Mutex
+	out    *bufio.Writer
+	filter filter
+	opts   pretty.Options
+}
+
+func (v *viewer) read(r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	for scanner.Scan() {
+		v.print(scanner.Bytes())
+	}
+
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+}
+
+func (v *viewer) print(line []byte) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	defer v.out.Flush()
+
+	entry := map[string]any{}
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+
+	if err := dec.Decode(&entry); err != nil {
+		if v.filter.empty() {
+			v.out.Write(line)
+			v.out.WriteByte('\n')
+		}
+		return
+	}
+
+	if v.filter.match(entry) {
+		v.out.WriteString(pretty.Format(entry, v.opts))
+	}
+}
+
+// followReader keeps polling the underlying reader after EOF, like tail -f.
+type followReader struct {
+	r        io.ReadCloser
+	interval time.Duration
+}
+
+func (f *followReader) Read(p []byte) (int, error) {
+	for {
+		n, err := f.r.Read(p)
+		if n > 0 || err != io.EOF {
+			return n, err
+		}
+		time.Sleep(f.interval)
+	}
+}
+
+func (f *followReader) Close() error {
+	return f.r.Close()
+}
+
+func fatalf(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, "slog-view: "+format+"\n", args...)
+	os.Exit(2)
+}
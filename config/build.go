@@ -0,0 +1,24 @@
+package config
+
+import "runtime/debug"
+
+// buildVersion returns the version of the main module, or the VCS revision it was built from, "v0.0.0" when neither is known,
+// e.g. under go run or go test.
+func buildVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "v0.0.0"
+	}
+
+	if v := info.Main.Version; v != "" && v != "(devel)" {
+		return v
+	}
+
+	for _, s := range info.Settings {
+		if s.Key == "vcs.revision" && s.Value != "" {
+			return s.Value
+		}
+	}
+
+	return "v0.0.0"
+}
@@ -1,12 +1,292 @@
 package config
 
-import "github.com/Sellsuki/sellsuki-go-logger/v2/level"
+import (
+	"errors"
+	"fmt"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/logid"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+type Encoding string
+
+const (
+	EncodingJSON    Encoding = "json"    // One JSON object per line, the default.
+	EncodingConsole Encoding = "console" // Human-readable output for local development, same as Readable.
+	EncodingLogfmt  Encoding = "logfmt"  // logfmt with nested data flattened into dotted keys.
+)
+
+// Profile selects how the JSON envelope is reshaped for a log backend, see the profile package.
+type Profile string
+
+const (
+	ProfileDefault Profile = ""        // The Sellsuki envelope as is.
+	ProfileGCP     Profile = "gcp"     // Google Cloud Logging: severity, logging.googleapis.com/trace, httpRequest.
+	ProfileDatadog Profile = "datadog" // Datadog: status, service, dd.trace_id, dd.span_id.
+	ProfileECS     Profile = "ecs"     // Elastic Common Schema: @timestamp, log.level, trace.id.
+	ProfileOTel    Profile = "otel"    // OpenTelemetry semantic conventions: severity_text, trace_id, http.request.method.
+)
 
 type Config struct {
-	LogLevel      level.Level
-	AppName       string
-	Version       string
-	MaxBodySize   int
-	Readable      bool
+	LogLevel    level.Level
+	AppName     string
+	Version     string
+	MaxBodySize int
+	Readable    bool
+	Encoding    Encoding
+	Profile     Profile // Profile only applies to the JSON encoding.
+
+	// HardCodedTime replaces the timestamp of every entry with a fixed string.
+	//
+	// Deprecated: use Clock with FixedClock, it keeps the configured time format.
 	HardCodedTime string
+
+	Clock         Clock         // Clock supplies the time of the entries, the system clock by default.
+	TimeZone      string        // TimeZone is the IANA name timestamps are written in, e.g. "UTC" or "Asia/Bangkok", the local time zone by default.
+	TimeFormat    TimeFormat    // TimeFormat is TimeFormatISO8601 by default.
+	TimePrecision time.Duration // TimePrecision truncates timestamps, e.g. time.Millisecond, and fixes the width of TimeFormatRFC3339Nano.
+
+	CallerSkip     int  // CallerSkip reports the caller of every entry more frames up the stack, e.g. behind a logging wrapper.
+	CallerFunction bool // CallerFunction adds the function name of the caller as "function".
+	FullCallerPath bool // FullCallerPath writes the full path of the caller file instead of package/file.go.
+
+	// TypeLevels overrides LogLevel per log type, e.g. {"db.query": level.Warn}.
+	TypeLevels map[string]level.Level
+
+	// Redact lists the data keys (case-insensitive, at any depth) whose values are replaced with "[REDACTED]" in every entry.
+	Redact []string
+
+	// LogID adds a unique, time-sortable "log_id" to every entry, e.g. to drop the duplicates of a retried batch at ingestion.
+	LogID LogIDFormat
+	// LogIDGenerator replaces the generator of LogID and enables log IDs, e.g. to get predictable IDs in tests.
+	LogIDGenerator logid.Generator
+
+	// FingerprintNormalizer strips the variable parts of messages before they are fingerprinted,
+	// zap_logger.NormalizeMessage by default.
+	FingerprintNormalizer func(string) string
+
+	Enrich   EnrichConfig
+	Sampling SamplingConfig
+	OTLP     OTLPConfig
+	Alert    AlertConfig
+}
+
+// Default returns the configuration used by slog.Init when none is given.
+func Default() Config {
+	return Config{
+		LogLevel:    level.Info,
+		AppName:     "unknown",
+		Version:     buildVersion(),
+		MaxBodySize: 1048576,
+	}
+}
+
+// LevelFor returns the minimum level written for a log type.
+func (c Config) LevelFor(logType string) level.Level {
+	if l, ok := c.TypeLevels[logType]; ok {
+		return l
+	}
+	return c.LogLevel
+}
+
+// MinLevel returns the lowest level written for any log type.
+func (c Config) MinLevel() level.Level {
+	min := c.LogLevel
+	for _, l := range c.TypeLevels {
+		if l.Less(min) {
+			min = l
+		}
+	}
+	return min
+}
+
+// Apply replaces dst with c, so a Config given to slog.Init sets every field, including zero values such as level.Info and false.
+// Start from Default or a preset and change its fields to keep the defaults of the others.
+func (c Config) Apply(dst *Config) {
+	*dst = c
+}
+
+// EnrichConfig adds the metadata of the process to every entry when Enabled:
+// "environment" and "region" when set, "host" with the hostname and PID,
+// "k8s" with the pod, namespace and node from the POD_NAME, POD_NAMESPACE and NODE_NAME environment variables
+// set through the Kubernetes downward API, and "build" with the VCS revision and Go version of the binary.
+type EnrichConfig struct {
+	Enabled     bool
+	Environment string // Environment is the deployment environment, e.g. "production".
+	Region      string // Region is the region or data center, e.g. "asia-southeast1".
+}
+
+// SamplingConfig limits each distinct level and message to Initial entries per Tick, then keeps one every Thereafter.
+// Zero values fall back to 100 entries, then every 100th, per second.
+type SamplingConfig struct {
+	Disabled   bool
+	Initial    int
+	Thereafter int
+	Tick       time.Duration
+}
+
+// OTLPConfig enables exporting every entry to an OpenTelemetry collector over OTLP/HTTP, in addition to stdout.
+// The exporter is disabled when Endpoint is empty, zero values fall back to the otlp package defaults.
+type OTLPConfig struct {
+	Endpoint      string // Endpoint is the OTLP/HTTP logs URL, e.g. http://otel-collector:4318/v1/logs.
+	Headers       map[string]string
+	BatchSize     int
+	MaxQueueSize  int // MaxQueueSize is the max number of records waiting for export, the oldest are dropped over it.
+	FlushInterval time.Duration
+	MaxRetries    int
+}
+
+// AlertConfig enables posting alert entries to webhooks, the dispatcher is disabled when Webhooks is empty.
+// Zero values fall back to the alert package defaults.
+type AlertConfig struct {
+	Webhooks     []AlertWebhook
+	DedupWindow  time.Duration // DedupWindow drops alerts with the same fingerprint within the window.
+	RateLimit    int           // RateLimit is the max number of alerts delivered per RateInterval.
+	RateInterval time.Duration
+}
+
+// AlertWebhook is a webhook destination, Kind is "slack", "line_notify" or "json".
+type AlertWebhook struct {
+	Kind     string            `json:"kind"`
+	URL      string            `json:"url"`
+	Token    string            `json:"token"`
+	Headers  map[string]string `json:"headers"`
+	Template string            `json:"template"` // Template is a text/template executed with an alert.Alert.
+
+	// Teams and Severities restrict the webhook to alerts with a matching alert_info, empty matches every alert.
+	Teams      []string `json:"teams"`
+	Severities []string `json:"severities"`
+}
+
+// Validate reports every invalid value of the configuration in a single error.
+func (c Config) Validate() error {
+	var errs fieldErrors
+
+	if _, err := c.LogLevel.MarshalText(); err != nil {
+		errs.add("log_level", "%v", err)
+	}
+	for _, t := range sortedKeys(c.TypeLevels) {
+		if _, err := c.TypeLevels[t].MarshalText(); err != nil {
+			errs.add("type_levels."+t, "%v", err)
+		}
+	}
+	if c.AppName == "" {
+		errs.add("app_name", "must not be empty")
+	}
+	if c.CallerSkip < 0 {
+		errs.add("caller_skip", "must not be negative")
+	}
+	if c.MaxBodySize < 0 {
+		errs.add("max_body_size", "must not be negative")
+	}
+
+	switch c.Encoding {
+	case "", EncodingJSON, EncodingConsole, EncodingLogfmt:
+	default:
+		errs.add("encoding", "unknown encoding %q", c.Encoding)
+	}
+
+	switch c.Profile {
+	case ProfileDefault, ProfileGCP, ProfileDatadog, ProfileECS, ProfileOTel:
+	default:
+		errs.add("profile", "unknown profile %q", c.Profile)
+	}
+
+	if c.TimeZone != "" {
+		if _, err := time.LoadLocation(c.TimeZone); err != nil {
+			errs.add("time_zone", "unknown time zone %q", c.TimeZone)
+		}
+	}
+	switch c.TimeFormat {
+	case "", TimeFormatISO8601, TimeFormatRFC3339, TimeFormatRFC3339Nano, TimeFormatEpochSeconds, TimeFormatEpochMillis, TimeFormatEpochNanos:
+	default:
+		errs.add("time_format", "unknown time format %q", c.TimeFormat)
+	}
+	if c.TimePrecision < 0 {
+		errs.add("time_precision", "must not be negative")
+	}
+
+	switch c.LogID {
+	case LogIDNone, LogIDULID, LogIDUUIDv7:
+	default:
+		errs.add("log_id", "unknown log id format %q, expected ulid or uuidv7", c.LogID)
+	}
+
+	if c.Sampling.Initial < 0 {
+		errs.add("sampling.initial", "must not be negative")
+	}
+	if c.Sampling.Thereafter < 0 {
+		errs.add("sampling.thereafter", "must not be negative")
+	}
+	if c.Sampling.Tick < 0 {
+		errs.add("sampling.tick", "must not be negative")
+	}
+
+	if c.OTLP.Endpoint != "" {
+		if u, err := url.Parse(c.OTLP.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs.add("otlp.endpoint", "must be an http or https URL, got %q", c.OTLP.Endpoint)
+		}
+	}
+	if c.OTLP.BatchSize < 0 {
+		errs.add("otlp.batch_size", "must not be negative")
+	}
+	if c.OTLP.MaxQueueSize < 0 {
+		errs.add("otlp.max_queue_size", "must not be negative")
+	}
+	if c.OTLP.FlushInterval < 0 {
+		errs.add("otlp.flush_interval", "must not be negative")
+	}
+
+	for i, w := range c.Alert.Webhooks {
+		key := fmt.Sprintf("alert.webhooks[%d]", i)
+		switch w.Kind {
+		case "", "slack", "json":
+			if w.URL == "" {
+				errs.add(key+".url", "must not be empty")
+			}
+		case "line_notify":
+			if w.Token == "" {
+				errs.add(key+".token", "must not be empty for line_notify")
+			}
+		default:
+			errs.add(key+".kind", "unknown webhook kind %q, expected slack, line_notify or json", w.Kind)
+		}
+	}
+	if c.Alert.DedupWindow < 0 {
+		errs.add("alert.dedup_window", "must not be negative")
+	}
+	if c.Alert.RateLimit < 0 {
+		errs.add("alert.rate_limit", "must not be negative")
+	}
+	if c.Alert.RateInterval < 0 {
+		errs.add("alert.rate_interval", "must not be negative")
+	}
+
+	return errs.err()
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// fieldErrors collects the problems found while loading or validating a configuration.
+type fieldErrors []string
+
+func (e *fieldErrors) add(key string, format string, args ...any) {
+	*e = append(*e, key+": "+fmt.Sprintf(format, args...))
+}
+
+func (e fieldErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return errors.New("invalid config: " + strings.Join(e, "; "))
 }
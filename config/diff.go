@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// secretKeys are reported as changed without their values.
+var secretKeys = map[string]bool{
+	"otlp.headers":   true,
+	"alert.webhooks": true,
+}
+
+// Diff describes every field that differs between two configurations using the field keys of FromFile,
+// e.g. "log_level: info -> debug". Function and interface fields such as Clock are ignored.
+func Diff(old, new Config) []string {
+	var changes []string
+	diff("", reflect.ValueOf(old), reflect.ValueOf(new), &changes)
+	return changes
+}
+
+func diff(prefix string, old, new reflect.Value, changes *[]string) {
+	for i := 0; i < old.NumField(); i++ {
+		f := old.Type().Field(i)
+		key := snakeCase(f.Name)
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		o, n := old.Field(i), new.Field(i)
+		switch {
+		case f.Type.Kind() == reflect.Func, f.Type.Kind() == reflect.Interface:
+		case f.Type.Kind() == reflect.Struct && f.Type.PkgPath() == old.Type().PkgPath():
+			diff(key, o, n, changes)
+		case reflect.DeepEqual(o.Interface(), n.Interface()):
+		case secretKeys[key]:
+			*changes = append(*changes, key+": changed")
+		default:
+			*changes = append(*changes, fmt.Sprintf("%s: %s -> %s", key, format(o.Interface()), format(n.Interface())))
+		}
+	}
+}
+
+func format(v any) string {
+	switch t := v.(type) {
+	case level.Level:
+		return t.String()
+	case map[string]level.Level:
+		pairs := make([]string, 0, len(t))
+		for _, k := range sortedKeys(t) {
+			pairs = append(pairs, k+"="+format(t[k]))
+		}
+		return "{" + strings.Join(pairs, ", ") + "}"
+	default:
+		if reflect.ValueOf(v).Kind() == reflect.String {
+			return fmt.Sprintf("%q", v)
+		}
+		return fmt.Sprint(v)
+	}
+}
+
+// snakeCase converts a Go field name into its key, e.g. MaxBodySize to max_body_size and OTLP to otlp.
+func snakeCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
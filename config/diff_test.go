@@ -0,0 +1,43 @@
+package config
+
+import (
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	old := Default()
+	assert.Empty(t, Diff(old, old))
+
+	new := Default()
+	new.LogLevel = level.Debug
+	new.Encoding = EncodingLogfmt
+	new.TypeLevels = map[string]level.Level{"db.query": level.Warn, "audit": level.Info}
+	new.Redact = []string{"password"}
+	new.Sampling.Tick = 5 * time.Second
+	new.OTLP.Headers = map[string]string{"api-key": "secret"}
+	new.FingerprintNormalizer = func(s string) string { return s }
+
+	assert.Equal(t, []string{
+		"log_level: info -> debug",
+		`encoding: "" -> "logfmt"`,
+		"type_levels: {} -> {audit=info, db.query=warn}",
+		"redact: [] -> [password]",
+		"sampling.tick: 0s -> 5s",
+		"otlp.headers: changed",
+	}, Diff(old, new))
+}
+
+func TestSnakeCase(t *testing.T) {
+	for name, want := range map[string]string{
+		"LogLevel":      "log_level",
+		"OTLP":          "otlp",
+		"MaxBodySize":   "max_body_size",
+		"HardCodedTime": "hard_coded_time",
+		"URL":           "url",
+	} {
+		assert.Equal(t, want, snakeCase(name))
+	}
+}
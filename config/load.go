@@ -0,0 +1,363 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// field is a configuration value settable from a file key or an environment variable.
+// Keys are snake case with dots for nesting, e.g. "otlp.batch_size" read from PREFIX_OTLP_BATCH_SIZE.
+type field struct {
+	key string
+	set func(c *Config, v any) error
+}
+
+var fields = []field{
+	{"log_level", func(c *Config, v any) (err error) { c.LogLevel, err = parseLevel(v); return }},
+	{"app_name", func(c *Config, v any) (err error) { c.AppName, err = toString(v); return }},
+	{"version", func(c *Config, v any) (err error) { c.Version, err = toString(v); return }},
+	{"max_body_size", func(c *Config, v any) (err error) { c.MaxBodySize, err = toInt(v); return }},
+	{"readable", func(c *Config, v any) (err error) { c.Readable, err = toBool(v); return }},
+	{"encoding", func(c *Config, v any) error {
+		s, err := toString(v)
+		c.Encoding = Encoding(s)
+		return err
+	}},
+	{"profile", func(c *Config, v any) error {
+		s, err := toString(v)
+		c.Profile = Profile(s)
+		return err
+	}},
+	{"hard_coded_time", func(c *Config, v any) (err error) { c.HardCodedTime, err = toString(v); return }},
+	{"time_zone", func(c *Config, v any) (err error) { c.TimeZone, err = toString(v); return }},
+	{"time_format", func(c *Config, v any) error {
+		s, err := toString(v)
+		c.TimeFormat = TimeFormat(s)
+		return err
+	}},
+	{"time_precision", func(c *Config, v any) (err error) { c.TimePrecision, err = toDuration(v); return }},
+	{"caller_skip", func(c *Config, v any) (err error) { c.CallerSkip, err = toInt(v); return }},
+	{"caller_function", func(c *Config, v any) (err error) { c.CallerFunction, err = toBool(v); return }},
+	{"full_caller_path", func(c *Config, v any) (err error) { c.FullCallerPath, err = toBool(v); return }},
+	{"type_levels", func(c *Config, v any) (err error) { c.TypeLevels, err = toLevelMap(v); return }},
+	{"redact", func(c *Config, v any) (err error) { c.Redact, err = toStringList(v); return }},
+	{"log_id", func(c *Config, v any) error {
+		s, err := toString(v)
+		c.LogID = LogIDFormat(s)
+		return err
+	}},
+	{"enrich.enabled", func(c *Config, v any) (err error) { c.Enrich.Enabled, err = toBool(v); return }},
+	{"enrich.environment", func(c *Config, v any) (err error) { c.Enrich.Environment, err = toString(v); return }},
+	{"enrich.region", func(c *Config, v any) (err error) { c.Enrich.Region, err = toString(v); return }},
+	{"sampling.disabled", func(c *Config, v any) (err error) { c.Sampling.Disabled, err = toBool(v); return }},
+	{"sampling.initial", func(c *Config, v any) (err error) { c.Sampling.Initial, err = toInt(v); return }},
+	{"sampling.thereafter", func(c *Config, v any) (err error) { c.Sampling.Thereafter, err = toInt(v); return }},
+	{"sampling.tick", func(c *Config, v any) (err error) { c.Sampling.Tick, err = toDuration(v); return }},
+	{"otlp.endpoint", func(c *Config, v any) (err error) { c.OTLP.Endpoint, err = toString(v); return }},
+	{"otlp.headers", func(c *Config, v any) (err error) { c.OTLP.Headers, err = toStringMap(v); return }},
+	{"otlp.batch_size", func(c *Config, v any) (err error) { c.OTLP.BatchSize, err = toInt(v); return }},
+	{"otlp.max_queue_size", func(c *Config, v any) (err error) { c.OTLP.MaxQueueSize, err = toInt(v); return }},
+	{"otlp.flush_interval", func(c *Config, v any) (err error) { c.OTLP.FlushInterval, err = toDuration(v); return }},
+	{"otlp.max_retries", func(c *Config, v any) (err error) { c.OTLP.MaxRetries, err = toInt(v); return }},
+	{"alert.webhooks", func(c *Config, v any) (err error) { c.Alert.Webhooks, err = toWebhooks(v); return }},
+	{"alert.dedup_window", func(c *Config, v any) (err error) { c.Alert.DedupWindow, err = toDuration(v); return }},
+	{"alert.rate_limit", func(c *Config, v any) (err error) { c.Alert.RateLimit, err = toInt(v); return }},
+	{"alert.rate_interval", func(c *Config, v any) (err error) { c.Alert.RateInterval, err = toDuration(v); return }},
+}
+
+// FromEnv returns the defaults overridden by the environment variables named after the
+// field keys with the given prefix, e.g. SLOG_LOG_LEVEL=debug or SLOG_OTLP_HEADERS=api-key=secret,team=payments.
+// alert.webhooks is read as a JSON array.
+func FromEnv(prefix string) (Config, error) {
+	c := Default()
+	if err := c.applyEnv(prefix); err != nil {
+		return Config{}, err
+	}
+	return c, c.Validate()
+}
+
+// FromFile returns the defaults overridden by a YAML (.yaml, .yml) or JSON (.json) file using the field keys,
+// nested under their section, e.g. "otlp: {batch_size: 50}".
+func FromFile(path string) (Config, error) {
+	c := Default()
+	if err := c.applyFile(path); err != nil {
+		return Config{}, err
+	}
+	return c, c.Validate()
+}
+
+// Load returns the defaults overridden by the file, then by the environment variables, see FromFile and FromEnv.
+// The file is skipped when path is empty.
+func Load(path string, prefix string) (Config, error) {
+	return LoadOver(Default(), path, prefix)
+}
+
+// LoadOver is Load starting from base instead of the defaults, e.g. the configuration set in code.
+func LoadOver(base Config, path string, prefix string) (Config, error) {
+	c := base
+	if path != "" {
+		if err := c.applyFile(path); err != nil {
+			return Config{}, err
+		}
+	}
+	if err := c.applyEnv(prefix); err != nil {
+		return Config{}, err
+	}
+	return c, c.Validate()
+}
+
+func envName(prefix, key string) string {
+	name := strings.ToUpper(strings.NewReplacer(".", "_").Replace(key))
+	if prefix == "" {
+		return name
+	}
+	return strings.TrimSuffix(prefix, "_") + "_" + name
+}
+
+func (c *Config) applyEnv(prefix string) error {
+	var errs fieldErrors
+
+	for _, f := range fields {
+		name := envName(prefix, f.key)
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+		if err := f.set(c, v); err != nil {
+			errs.add(name, "%v", err)
+		}
+	}
+
+	return errs.err()
+}
+
+func (c *Config) applyFile(path string) error {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext != ".json" && ext != ".yaml" && ext != ".yml" {
+		return fmt.Errorf("invalid config: %s: unsupported file extension %q, expected .yaml, .yml or .json", path, ext)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	values := map[string]any{}
+	if ext == ".json" {
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.UseNumber()
+		err = dec.Decode(&values)
+	} else {
+		err = yaml.Unmarshal(b, &values)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid config: %s: %w", path, err)
+	}
+
+	byKey := map[string]field{}
+	for _, f := range fields {
+		byKey[f.key] = f
+	}
+
+	var errs fieldErrors
+	c.applyValues("", values, byKey, &errs)
+
+	for i := range errs {
+		errs[i] = path + ": " + errs[i]
+	}
+
+	return errs.err()
+}
+
+func (c *Config) applyValues(prefix string, values map[string]any, byKey map[string]field, errs *fieldErrors) {
+	for _, k := range sortedKeys(values) {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+
+		if f, ok := byKey[key]; ok {
+			if err := f.set(c, values[k]); err != nil {
+				errs.add(key, "%v", err)
+			}
+			continue
+		}
+
+		if nested, ok := values[k].(map[string]any); ok {
+			c.applyValues(key, nested, byKey, errs)
+			continue
+		}
+
+		errs.add(key, "unknown key")
+	}
+}
+
+func parseLevel(v any) (level.Level, error) {
+	s, err := toString(v)
+	if err != nil {
+		return 0, err
+	}
+
+	return level.Parse(s)
+}
+
+func toString(v any) (string, error) {
+	switch t := v.(type) {
+	case string:
+		return t, nil
+	case json.Number, int, float64, bool:
+		return fmt.Sprint(t), nil
+	default:
+		return "", fmt.Errorf("expected a string, got %T", v)
+	}
+}
+
+func toInt(v any) (int, error) {
+	switch t := v.(type) {
+	case int:
+		return t, nil
+	case json.Number, string:
+		n, err := strconv.Atoi(strings.TrimSpace(fmt.Sprint(t)))
+		if err != nil {
+			return 0, fmt.Errorf("invalid integer %q", fmt.Sprint(t))
+		}
+		return n, nil
+	default:
+		return 0, fmt.Errorf("expected an integer, got %T", v)
+	}
+}
+
+func toBool(v any) (bool, error) {
+	switch t := v.(type) {
+	case bool:
+		return t, nil
+	case string:
+		b, err := strconv.ParseBool(strings.TrimSpace(t))
+		if err != nil {
+			return false, fmt.Errorf("invalid boolean %q", t)
+		}
+		return b, nil
+	default:
+		return false, fmt.Errorf("expected a boolean, got %T", v)
+	}
+}
+
+func toDuration(v any) (time.Duration, error) {
+	s, ok := v.(string)
+	if !ok {
+		return 0, fmt.Errorf("expected a duration such as \"5s\", got %T", v)
+	}
+
+	d, err := time.ParseDuration(strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return d, nil
+}
+
+// toStringMap accepts an object or a comma separated list of key=value pairs.
+func toStringMap(v any) (map[string]string, error) {
+	out := map[string]string{}
+
+	switch t := v.(type) {
+	case map[string]any:
+		for k, val := range t {
+			s, err := toString(val)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+			out[k] = s
+		}
+	case string:
+		for _, pair := range strings.Split(t, ",") {
+			if strings.TrimSpace(pair) == "" {
+				continue
+			}
+			k, val, ok := strings.Cut(pair, "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid pair %q, expected key=value", pair)
+			}
+			out[strings.TrimSpace(k)] = strings.TrimSpace(val)
+		}
+	default:
+		return nil, fmt.Errorf("expected an object or key=value pairs, got %T", v)
+	}
+
+	return out, nil
+}
+
+// toLevelMap accepts an object or a comma separated list of type=level pairs.
+func toLevelMap(v any) (map[string]level.Level, error) {
+	m, err := toStringMap(v)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]level.Level, len(m))
+	for t, name := range m {
+		if out[t], err = parseLevel(name); err != nil {
+			return nil, fmt.Errorf("%s: %w", t, err)
+		}
+	}
+
+	return out, nil
+}
+
+// toStringList accepts a list or a comma separated string.
+func toStringList(v any) ([]string, error) {
+	var out []string
+
+	switch t := v.(type) {
+	case []any:
+		for _, val := range t {
+			s, err := toString(val)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, s)
+		}
+	case string:
+		for _, s := range strings.Split(t, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				out = append(out, s)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("expected a list, got %T", v)
+	}
+
+	return out, nil
+}
+
+// toWebhooks accepts a list of objects or its JSON encoding.
+func toWebhooks(v any) ([]AlertWebhook, error) {
+	var b []byte
+	switch t := v.(type) {
+	case string:
+		b = []byte(t)
+	case []any:
+		var err error
+		if b, err = json.Marshal(t); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("expected a list of webhooks, got %T", v)
+	}
+
+	var webhooks []AlertWebhook
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&webhooks); err != nil {
+		return nil, fmt.Errorf("invalid webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestFromFile_YAML(t *testing.T) {
+	c, err := FromFile("testdata/config.yaml")
+	assert.NoError(t, err)
+
+	assert.Equal(t, Config{
+		LogLevel:    level.Warn,
+		AppName:     "order-service",
+		Version:     "v1.2.3",
+		MaxBodySize: 2048,
+		Profile:     ProfileGCP,
+		Sampling:    SamplingConfig{Initial: 10, Thereafter: 50, Tick: 2 * time.Second},
+		OTLP: OTLPConfig{
+			Endpoint:      "http://otel-collector:4318/v1/logs",
+			Headers:       map[string]string{"api-key": "secret"},
+			BatchSize:     50,
+			FlushInterval: time.Second,
+		},
+		Alert: AlertConfig{
+			Webhooks:    []AlertWebhook{{Kind: "slack", URL: "https://hooks.slack.com/services/x", Teams: []string{"payments"}}},
+			DedupWindow: 10 * time.Minute,
+			RateLimit:   5,
+		},
+	}, c)
+}
+
+func TestFromFile_JSON(t *testing.T) {
+	c, err := FromFile("testdata/config.json")
+	assert.NoError(t, err)
+
+	want := Default()
+	want.LogLevel = level.Debug
+	want.AppName = "order-service"
+	want.Sampling.Disabled = true
+	want.OTLP.BatchSize = 20
+	assert.Equal(t, want, c)
+}
+
+func TestFromFile_Invalid(t *testing.T) {
+	_, err := FromFile("testdata/invalid.yaml")
+	assert.EqualError(t, err, `invalid config: `+
+		`testdata/invalid.yaml: log_level: unknown level "verbose", expected trace, debug, info, notice, warn, error, panic or fatal; `+
+		`testdata/invalid.yaml: otlp.batchsize: unknown key; `+
+		`testdata/invalid.yaml: sampling.tick: expected a duration such as "5s", got int`)
+
+	_, err = FromFile("testdata/missing.yaml")
+	assert.ErrorContains(t, err, "invalid config: open testdata/missing.yaml")
+
+	_, err = FromFile("testdata/config.toml")
+	assert.EqualError(t, err, `invalid config: testdata/config.toml: unsupported file extension ".toml", expected .yaml, .yml or .json`)
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("SLOG_LOG_LEVEL", "WARNING")
+	t.Setenv("SLOG_APP_NAME", "order-service")
+	t.Setenv("SLOG_READABLE", "true")
+	t.Setenv("SLOG_TYPE_LEVELS", "db.query=debug, audit=error")
+	t.Setenv("SLOG_REDACT", "password, card_number")
+	t.Setenv("SLOG_ENRICH_ENABLED", "1")
+	t.Setenv("SLOG_ENRICH_ENVIRONMENT", "production")
+	t.Setenv("SLOG_SAMPLING_THEREAFTER", "10")
+	t.Setenv("SLOG_OTLP_ENDPOINT", "https://collector/v1/logs")
+	t.Setenv("SLOG_OTLP_HEADERS", "api-key=secret, team=payments")
+	t.Setenv("SLOG_OTLP_FLUSH_INTERVAL", "500ms")
+	t.Setenv("SLOG_ALERT_WEBHOOKS", `[{"kind":"line_notify","token":"t"}]`)
+
+	c, err := FromEnv("SLOG")
+	assert.NoError(t, err)
+
+	want := Default()
+	want.LogLevel = level.Warn
+	want.AppName = "order-service"
+	want.Readable = true
+	want.TypeLevels = map[string]level.Level{"db.query": level.Debug, "audit": level.Error}
+	want.Redact = []string{"password", "card_number"}
+	want.Enrich = EnrichConfig{Enabled: true, Environment: "production"}
+	want.Sampling.Thereafter = 10
+	want.OTLP = OTLPConfig{
+		Endpoint:      "https://collector/v1/logs",
+		Headers:       map[string]string{"api-key": "secret", "team": "payments"},
+		FlushInterval: 500 * time.Millisecond,
+	}
+	want.Alert.Webhooks = []AlertWebhook{{Kind: "line_notify", Token: "t"}}
+	assert.Equal(t, want, c)
+}
+
+func TestFromEnv_Invalid(t *testing.T) {
+	t.Setenv("SLOG_MAX_BODY_SIZE", "1MB")
+	t.Setenv("SLOG_READABLE", "yes please")
+	t.Setenv("SLOG_APP_NAME", "")
+
+	_, err := FromEnv("SLOG_")
+	assert.EqualError(t, err, `invalid config: SLOG_MAX_BODY_SIZE: invalid integer "1MB"; SLOG_READABLE: invalid boolean "yes please"`)
+
+	t.Setenv("SLOG_MAX_BODY_SIZE", "10")
+	t.Setenv("SLOG_READABLE", "1")
+
+	_, err = FromEnv("SLOG")
+	assert.EqualError(t, err, `invalid config: app_name: must not be empty`)
+}
+
+func TestLoad(t *testing.T) {
+	t.Setenv("SLOG_LOG_LEVEL", "error")
+	t.Setenv("SLOG_OTLP_BATCH_SIZE", "5")
+
+	c, err := Load("testdata/config.yaml", "SLOG")
+	assert.NoError(t, err)
+
+	assert.Equal(t, level.Error, c.LogLevel)
+	assert.Equal(t, "order-service", c.AppName)
+	assert.Equal(t, 5, c.OTLP.BatchSize)
+	assert.Equal(t, time.Second, c.OTLP.FlushInterval)
+
+	c, err = Load("", "SLOG")
+	assert.NoError(t, err)
+	assert.Equal(t, "unknown", c.AppName)
+
+	base := Default()
+	base.Redact = []string{"password"}
+	base.MaxBodySize = 10
+	c, err = LoadOver(base, "testdata/config.yaml", "SLOG")
+	assert.NoError(t, err)
+	assert.Equal(t, "order-service", c.AppName)
+	assert.Equal(t, 2048, c.MaxBodySize)
+	assert.Equal(t, []string{"password"}, c.Redact, "settings missing from the file keep the base")
+	assert.Equal(t, level.Error, c.LogLevel)
+}
+
+func TestValidate(t *testing.T) {
+	c := Default()
+	c.Encoding = "xml"
+	c.OTLP.Endpoint = "collector:4318"
+	c.Alert.Webhooks = []AlertWebhook{{Kind: "teams"}, {Kind: "slack"}}
+	c.Alert.RateLimit = -1
+
+	assert.EqualError(t, c.Validate(), `invalid config: encoding: unknown encoding "xml"; `+
+		`otlp.endpoint: must be an http or https URL, got "collector:4318"; `+
+		`alert.webhooks[0].kind: unknown webhook kind "teams", expected slack, line_notify or json; `+
+		`alert.webhooks[1].url: must not be empty; `+
+		`alert.rate_limit: must not be negative`)
+
+	assert.NoError(t, Default().Validate())
+
+	c = Default()
+	c.TimeZone = "Mars/Olympus"
+	c.TimeFormat = "unix"
+	c.TimePrecision = -time.Millisecond
+	c.LogID = "uuidv4"
+	assert.EqualError(t, c.Validate(), `invalid config: time_zone: unknown time zone "Mars/Olympus"; `+
+		`time_format: unknown time format "unix"; `+
+		`time_precision: must not be negative; `+
+		`log_id: unknown log id format "uuidv4", expected ulid or uuidv7`)
+}
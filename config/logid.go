@@ -0,0 +1,36 @@
+package config
+
+import (
+	"github.com/Sellsuki/sellsuki-go-logger/v2/logid"
+	"time"
+)
+
+// LogIDFormat selects the built-in generator of the "log_id" of every entry.
+type LogIDFormat string
+
+const (
+	LogIDNone   LogIDFormat = ""       // No log_id, the default.
+	LogIDULID   LogIDFormat = "ulid"   // 26 character ULID, see logid.ULID.
+	LogIDUUIDv7 LogIDFormat = "uuidv7" // Version 7 UUID, see logid.UUIDv7.
+)
+
+// NewLogID returns a new ID for an entry from LogIDGenerator or the LogID format, "" when log IDs are disabled.
+func (c Config) NewLogID() string {
+	if c.LogIDGenerator == nil && c.LogID == LogIDNone {
+		return ""
+	}
+
+	now := time.Now()
+	if c.Clock != nil {
+		now = c.Clock.Now()
+	}
+
+	switch {
+	case c.LogIDGenerator != nil:
+		return c.LogIDGenerator(now)
+	case c.LogID == LogIDUUIDv7:
+		return logid.UUIDv7(now)
+	default:
+		return logid.ULID(now)
+	}
+}
@@ -0,0 +1,23 @@
+package config
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewLogID(t *testing.T) {
+	clock := FixedClock(time.Date(2023, 11, 9, 7, 48, 14, 803000000, time.UTC))
+
+	assert.Empty(t, Config{}.NewLogID())
+	assert.Regexp(t, `^01HESGV6AK[0-9A-Z]{16}$`, Config{LogID: LogIDULID, Clock: clock}.NewLogID())
+	assert.Regexp(t, `^018bb30d-9953-7`, Config{LogID: LogIDUUIDv7, Clock: clock}.NewLogID())
+
+	var got time.Time
+	id := Config{Clock: clock, LogIDGenerator: func(t time.Time) string {
+		got = t
+		return "id"
+	}}.NewLogID()
+	assert.Equal(t, "id", id)
+	assert.Equal(t, clock.Now(), got)
+}
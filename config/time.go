@@ -0,0 +1,31 @@
+package config
+
+import "time"
+
+// TimeFormat selects how the timestamp of an entry is written.
+type TimeFormat string
+
+const (
+	TimeFormatISO8601      TimeFormat = "iso8601"       // 2006-01-02T15:04:05.000Z0700, the default.
+	TimeFormatRFC3339      TimeFormat = "rfc3339"       // 2006-01-02T15:04:05Z07:00.
+	TimeFormatRFC3339Nano  TimeFormat = "rfc3339nano"   // 2006-01-02T15:04:05.999999999Z07:00, fixed width when TimePrecision is set.
+	TimeFormatEpochSeconds TimeFormat = "epoch_seconds" // Seconds since the Unix epoch as a floating point number.
+	TimeFormatEpochMillis  TimeFormat = "epoch_millis"  // Milliseconds since the Unix epoch as an integer.
+	TimeFormatEpochNanos   TimeFormat = "epoch_nanos"   // Nanoseconds since the Unix epoch as an integer.
+)
+
+// Clock supplies the time of every entry, e.g. a FixedClock for deterministic output in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+// FixedClock returns a Clock always returning t.
+func FixedClock(t time.Time) Clock {
+	return fixedClock(t)
+}
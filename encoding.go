@@ -0,0 +1,125 @@
+package slog
+
+import (
+	"fmt"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/config"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/logfmt"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/pretty"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/profile"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"os"
+	"strings"
+	"time"
+)
+
+// zap encodings registered for config.EncodingConsole and config.EncodingLogfmt.
+const (
+	consoleEncoding = "sellsuki-console"
+	logfmtEncoding  = "sellsuki-logfmt"
+)
+
+// profileEncodingPrefix prefixes the zap encoding registered for each config.Profile.
+const profileEncodingPrefix = "sellsuki-json-"
+
+func init() {
+	_ = zap.RegisterEncoder(consoleEncoding, func(cfg zapcore.EncoderConfig) (zapcore.Encoder, error) {
+		return pretty.NewEncoder(cfg, pretty.Options{Color: pretty.IsTerminal(os.Stdout)}), nil
+	})
+	_ = zap.RegisterEncoder(logfmtEncoding, func(cfg zapcore.EncoderConfig) (zapcore.Encoder, error) {
+		return logfmt.NewEncoder(cfg), nil
+	})
+	for _, p := range profile.Profiles {
+		p := p
+		_ = zap.RegisterEncoder(profileEncodingPrefix+string(p), func(cfg zapcore.EncoderConfig) (zapcore.Encoder, error) {
+			return profile.NewEncoder(cfg, p, profile.Options{GCPProjectID: os.Getenv("GOOGLE_CLOUD_PROJECT")})
+		})
+	}
+}
+
+// zapEncoding returns the zap encoding name for the configured encoding, Readable takes precedence.
+// The profile is only applied to JSON.
+func zapEncoding(cfg config.Config) string {
+	if cfg.Readable {
+		return consoleEncoding
+	}
+
+	switch cfg.Encoding {
+	case "", config.EncodingJSON:
+		if cfg.Profile != config.ProfileDefault {
+			return profileEncodingPrefix + string(cfg.Profile)
+		}
+		return "json"
+	case config.EncodingConsole:
+		return consoleEncoding
+	case config.EncodingLogfmt:
+		return logfmtEncoding
+	default:
+		return string(cfg.Encoding)
+	}
+}
+
+// timeEncoder returns the zap time encoder for the configured time zone, format and precision.
+// HardCodedTime takes precedence.
+func timeEncoder(cfg config.Config) (zapcore.TimeEncoder, error) {
+	if cfg.HardCodedTime != "" {
+		return func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+			enc.AppendString(cfg.HardCodedTime)
+		}, nil
+	}
+
+	var loc *time.Location
+	if cfg.TimeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(cfg.TimeZone); err != nil {
+			return nil, err
+		}
+	}
+
+	var format func(t time.Time, enc zapcore.PrimitiveArrayEncoder)
+	switch cfg.TimeFormat {
+	case "", config.TimeFormatISO8601:
+		format = zapcore.ISO8601TimeEncoder
+	case config.TimeFormatRFC3339:
+		format = zapcore.RFC3339TimeEncoder
+	case config.TimeFormatRFC3339Nano:
+		layout := time.RFC3339Nano
+		if digits := fractionDigits(cfg.TimePrecision); digits >= 0 {
+			layout = "2006-01-02T15:04:05" + strings.TrimSuffix("."+strings.Repeat("0", digits), ".") + "Z07:00"
+		}
+		format = zapcore.TimeEncoderOfLayout(layout)
+	case config.TimeFormatEpochSeconds:
+		format = zapcore.EpochTimeEncoder
+	case config.TimeFormatEpochMillis:
+		format = func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+			enc.AppendInt64(t.UnixNano() / int64(time.Millisecond))
+		}
+	case config.TimeFormatEpochNanos:
+		format = zapcore.EpochNanosTimeEncoder
+	default:
+		return nil, fmt.Errorf("unknown time format %q", cfg.TimeFormat)
+	}
+
+	return func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+		if loc != nil {
+			t = t.In(loc)
+		}
+		if cfg.TimePrecision > 0 {
+			t = t.Truncate(cfg.TimePrecision)
+		}
+		format(t, enc)
+	}, nil
+}
+
+// fractionDigits returns the number of fractional second digits kept by a precision, -1 when it is not set.
+func fractionDigits(precision time.Duration) int {
+	if precision <= 0 {
+		return -1
+	}
+
+	digits := 0
+	for p := time.Second; p > precision && digits < 9; p /= 10 {
+		digits++
+	}
+	return digits
+}
@@ -0,0 +1,87 @@
+package slog
+
+import (
+	"bytes"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/config"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"testing"
+	"time"
+)
+
+func TestZapEncoding(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.Config
+		want string
+	}{
+		{name: "Default", cfg: config.Config{}, want: "json"},
+		{name: "JSON", cfg: config.Config{Encoding: config.EncodingJSON}, want: "json"},
+		{name: "Console", cfg: config.Config{Encoding: config.EncodingConsole}, want: consoleEncoding},
+		{name: "Readable", cfg: config.Config{Readable: true, Encoding: config.EncodingLogfmt}, want: consoleEncoding},
+		{name: "Logfmt", cfg: config.Config{Encoding: config.EncodingLogfmt}, want: logfmtEncoding},
+		{name: "Profile", cfg: config.Config{Profile: config.ProfileGCP}, want: "sellsuki-json-gcp"},
+		{name: "ProfileConsole", cfg: config.Config{Encoding: config.EncodingConsole, Profile: config.ProfileGCP}, want: consoleEncoding},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, zapEncoding(tt.cfg))
+		})
+	}
+}
+
+func TestTimeEncoder(t *testing.T) {
+	ts := time.Date(2023, 11, 9, 7, 48, 14, 803456789, time.UTC)
+
+	tests := []struct {
+		name string
+		cfg  config.Config
+		want string
+	}{
+		{name: "Default", cfg: config.Config{TimeZone: "UTC"}, want: `"2023-11-09T07:48:14.803Z"`},
+		{name: "TimeZone", cfg: config.Config{TimeZone: "Asia/Bangkok"}, want: `"2023-11-09T14:48:14.803+0700"`},
+		{name: "RFC3339", cfg: config.Config{TimeZone: "UTC", TimeFormat: config.TimeFormatRFC3339}, want: `"2023-11-09T07:48:14Z"`},
+		{name: "RFC3339Nano", cfg: config.Config{TimeZone: "Asia/Bangkok", TimeFormat: config.TimeFormatRFC3339Nano}, want: `"2023-11-09T14:48:14.803456789+07:00"`},
+		{name: "RFC3339NanoPrecision", cfg: config.Config{TimeZone: "UTC", TimeFormat: config.TimeFormatRFC3339Nano, TimePrecision: time.Microsecond}, want: `"2023-11-09T07:48:14.803456Z"`},
+		{name: "RFC3339NanoSeconds", cfg: config.Config{TimeZone: "UTC", TimeFormat: config.TimeFormatRFC3339Nano, TimePrecision: time.Second}, want: `"2023-11-09T07:48:14Z"`},
+		{name: "EpochSeconds", cfg: config.Config{TimeFormat: config.TimeFormatEpochSeconds, TimePrecision: time.Second}, want: `1699516094`},
+		{name: "EpochMillis", cfg: config.Config{TimeFormat: config.TimeFormatEpochMillis}, want: `1699516094803`},
+		{name: "EpochNanos", cfg: config.Config{TimeFormat: config.TimeFormatEpochNanos}, want: `1699516094803456789`},
+		{name: "HardCodedTime", cfg: config.Config{HardCodedTime: "fixed", TimeFormat: config.TimeFormatEpochMillis}, want: `"fixed"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encodeTime, err := timeEncoder(tt.cfg)
+			assert.NoError(t, err)
+
+			enc := zapcore.NewJSONEncoder(zapcore.EncoderConfig{TimeKey: "ts", EncodeTime: encodeTime})
+			buf, err := enc.EncodeEntry(zapcore.Entry{Time: ts}, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, `{"ts":`+tt.want+"}\n", buf.String())
+		})
+	}
+
+	_, err := timeEncoder(config.Config{TimeFormat: "unix"})
+	assert.EqualError(t, err, `unknown time format "unix"`)
+}
+
+func TestClock(t *testing.T) {
+	var buf bytes.Buffer
+	logger, _, err := build(config.Config{
+		Clock:      config.FixedClock(time.Date(2023, 11, 9, 14, 48, 14, 803000000, time.UTC)),
+		TimeZone:   "Asia/Bangkok",
+		TimeFormat: config.TimeFormatRFC3339Nano,
+		Sampling:   config.SamplingConfig{Disabled: true},
+	}, sinks{})
+	assert.NoError(t, err)
+
+	logger = logger.WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+		return zapcore.NewCore(zapcore.NewJSONEncoder(zapcore.EncoderConfig{TimeKey: "ts", EncodeTime: zapcore.RFC3339NanoTimeEncoder}), zapcore.AddSync(&buf), zapcore.DebugLevel)
+	}))
+	logger.Info("message")
+
+	assert.Equal(t, `{"ts":"2023-11-09T14:48:14.803Z"}`+"\n", buf.String())
+}
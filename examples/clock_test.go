@@ -0,0 +1,26 @@
+//go:build example
+// +build example
+
+package examples
+
+import (
+	slog "github.com/Sellsuki/sellsuki-go-logger/v2"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/config"
+	"time"
+)
+
+func Example_clock() {
+	// Do this once in bootstrap file AKA main.go
+	slog.Init(config.Config{
+		AppName:     "sampleApp",
+		Version:     "v1.0.0",
+		MaxBodySize: 1048576,
+		Clock:       config.FixedClock(time.Date(2023, 11, 9, 7, 48, 14, 803000000, time.UTC)),
+		TimeZone:    "Asia/Bangkok",
+	})
+
+	slog.Info("Info message").Write()
+
+	// Output:
+	// {"level":"info","timestamp":"2023-11-09T14:48:14.803+0700","caller":"examples/clock_test.go:22","message":"Info message","app_name":"sampleApp","version":"v1.0.0","alert":0,"log_type":"application","data":{}}
+}
@@ -55,5 +55,5 @@ func Example_handler_http_log() {
 	// Output:
 	//{"level":"info","timestamp":"2023-11-09T14:48:14.803+0700","caller":"examples/handler_http_log_test.go:35","message":"HandlerHTTP request received","app_name":"sampleApp","version":"v1.0.0","alert":0,"log_type":"handler.http","data":{"http_request":{"method":"POST","handler":"GetResourceById","path":"/api/{{resource}}","remote_ip":"192.168.1.1","headers":{"Content-Type":"application/json"},"params":{"resource":"123"},"query":{"param1":"value1"},"body":"{\"key\": \"value\"}","request_id":"unique-request-id"}}}
 	//{"level":"info","timestamp":"2023-11-09T14:48:14.803+0700","caller":"examples/handler_http_log_test.go:47","message":"HandlerHTTP request processed successfully","app_name":"sampleApp","version":"v1.0.0","alert":0,"log_type":"handler.http","data":{"http_response":{"status":200,"duration":2,"body":"{\"result\": \"success\"}","request_id":"unique-request-id","headers":null}}}
-	//{"level":"info","timestamp":"2023-11-09T14:48:14.803+0700","caller":"examples/handler_http_log_test.go:53","message":"HandlerHTTP request processing failed","app_name":"sampleApp","version":"v1.0.0","alert":0,"log_type":"handler.http","data":{"error":"error message here","sampleApp":{"field2":"value2"}}}
+	//{"level":"info","timestamp":"2023-11-09T14:48:14.803+0700","caller":"examples/handler_http_log_test.go:53","message":"HandlerHTTP request processing failed","app_name":"sampleApp","version":"v1.0.0","alert":0,"log_type":"handler.http","data":{"error":"error message here","fingerprint":"ff335ea2c185e306","sampleApp":{"field2":"value2"}}}
 }
@@ -0,0 +1,223 @@
+package slog
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/log"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/zap_logger"
+	"go.opentelemetry.io/otel/trace"
+	"io"
+	"net/http"
+	"time"
+)
+
+// HTTPClientTransport is an http.RoundTripper that writes a client.http log for every outgoing request
+// and injects the W3C trace context of the request context into the outgoing headers.
+// Only the first MaxBodySize bytes of a body are buffered for the log, the rest is streamed.
+//
+//	client := &http.Client{Transport: &slog.HTTPClientTransport{LogBody: true, RedactFields: []string{"card_number"}}}
+type HTTPClientTransport struct {
+	Base         http.RoundTripper // The underlying transport, http.DefaultTransport if nil.
+	LogBody      bool              // Log request and response bodies.
+	MaxBodySize  int               // Max size of logged bodies in bytes, falls back to config.MaxBodySize when 0.
+	RedactFields []string          // JSON keys whose values are replaced before bodies are logged, bodies that are not JSON are replaced whole.
+	MaxRetries   int               // Number of retries on transport errors and 502/503/504 responses.
+	RetryBackoff time.Duration     // Delay before each retry, multiplied by the attempt number.
+
+	// RetryNonIdempotent also retries POST and PATCH requests without an Idempotency-Key header,
+	// e.g. when the server deduplicates them. Requests with a body are only retried when they set GetBody.
+	RetryNonIdempotent bool
+}
+
+func (t *HTTPClientTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+
+	out := req.Clone(req.Context())
+	injectTraceContext(out)
+
+	payload := &log.HTTPClientPayload{
+		Method:       out.Method,
+		Host:         out.URL.Host,
+		Path:         out.URL.Path,
+		RequestSize:  out.ContentLength,
+		ResponseSize: -1,
+	}
+
+	max := t.maxBodySize()
+
+	if t.LogBody && out.Body != nil && out.Body != http.NoBody {
+		body, rest, complete, err := bufferBody(out.Body, max)
+		if err != nil {
+			out.Body.Close()
+			return nil, err
+		}
+
+		out.Body = rest
+		if complete {
+			out.GetBody = func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(body)), nil
+			}
+			payload.RequestSize = int64(len(body))
+		}
+		payload.RequestBody = t.formatBody(body, complete, max)
+	}
+
+	resp, err := t.roundTripWithRetry(out, payload)
+	payload.Duration = time.Since(start).Seconds()
+
+	// A failed read of the response body is logged, the caller gets the same error when it reads the body.
+	var readErr error
+	if resp != nil {
+		payload.Status = int64(resp.StatusCode)
+		payload.ResponseSize = resp.ContentLength
+
+		if t.LogBody && resp.Body != nil {
+			var body []byte
+			var complete bool
+			body, resp.Body, complete, readErr = bufferBody(resp.Body, max)
+			if complete {
+				payload.ResponseSize = int64(len(body))
+			}
+			payload.ResponseBody = t.formatBody(body, complete, max)
+		}
+	}
+
+	l := HTTPClient("HTTPClient request completed", payload)
+	switch {
+	case err != nil:
+		l = l.SetMessage("HTTPClient request failed").SetLevel(level.Error).WithError(err)
+	case readErr != nil:
+		l = l.SetMessage("HTTPClient response body read failed").SetLevel(level.Error).WithError(readErr)
+	case payload.Status >= http.StatusInternalServerError:
+		l = l.SetLevel(level.Error)
+	case payload.Status >= http.StatusBadRequest:
+		l = l.SetLevel(level.Warn)
+	}
+
+	if sc := trace.SpanContextFromContext(req.Context()); sc.IsValid() {
+		l = l.WithTracing(sc)
+	}
+
+	l.Write()
+
+	return resp, err
+}
+
+func (t *HTTPClientTransport) roundTripWithRetry(req *http.Request, payload *log.HTTPClientPayload) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	resp, err := base.RoundTrip(req)
+	for attempt := 1; attempt <= t.MaxRetries && t.canRetry(req) && shouldRetry(resp, err); attempt++ {
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				break
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				break
+			}
+			req.Body = body
+		}
+
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(t.RetryBackoff * time.Duration(attempt)):
+		}
+
+		payload.Retries++
+		resp, err = base.RoundTrip(req)
+	}
+
+	return resp, err
+}
+
+// canRetry reports whether req may be sent again, like net/http it treats methods other than POST and PATCH
+// and requests with an Idempotency-Key header as idempotent.
+func (t *HTTPClientTransport) canRetry(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	switch req.Method {
+	case http.MethodPost, http.MethodPatch:
+		return t.RetryNonIdempotent || req.Header.Get("Idempotency-Key") != "" || req.Header.Get("X-Idempotency-Key") != ""
+	default:
+		return true
+	}
+}
+
+func (t *HTTPClientTransport) maxBodySize() int {
+	if t.MaxBodySize == 0 && sukiLogger != nil {
+		return sukiLogger.currentConfig().MaxBodySize
+	}
+	return t.MaxBodySize
+}
+
+// formatBody redacts and truncates a logged body. A body cut at max cannot be redacted,
+// it is replaced with the placeholder when fields must be redacted so they cannot leak.
+func (t *HTTPClientTransport) formatBody(body []byte, complete bool, max int) string {
+	if !complete && len(t.RedactFields) > 0 {
+		return zap_logger.RedactedValue
+	}
+
+	return truncateBody(redactJSON(body, t.RedactFields), max)
+}
+
+// bufferBody reads up to max bytes of body for the log, 0 means unlimited. It returns the bytes read,
+// a body replaying them followed by the unread rest, and whether body was read to the end.
+func bufferBody(body io.ReadCloser, max int) ([]byte, io.ReadCloser, bool, error) {
+	r := io.Reader(body)
+	if max > 0 {
+		r = io.LimitReader(body, int64(max)+1)
+	}
+
+	buf, err := io.ReadAll(r)
+	complete := err == nil && (max <= 0 || len(buf) <= max)
+
+	return buf, readCloser{Reader: io.MultiReader(bytes.NewReader(buf), body), Closer: body}, complete, err
+}
+
+// readCloser closes the original body of a reader wrapping it.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// injectTraceContext sets the W3C traceparent and tracestate headers from the span in the request context.
+func injectTraceContext(req *http.Request) {
+	sc := trace.SpanContextFromContext(req.Context())
+	if !sc.IsValid() {
+		return
+	}
+
+	if req.Header == nil {
+		req.Header = http.Header{}
+	}
+
+	req.Header.Set("traceparent", fmt.Sprintf("00-%s-%s-%s", sc.TraceID(), sc.SpanID(), sc.TraceFlags()))
+	if ts := sc.TraceState().String(); ts != "" {
+		req.Header.Set("tracestate", ts)
+	}
+}
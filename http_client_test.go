@@ -0,0 +1,210 @@
+package slog
+
+import (
+	"context"
+	"errors"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/config"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestHTTPClientTransport_RoundTrip(t *testing.T) {
+	buf := useBufferLogger(t, config.Config{AppName: "app", Version: "v1", MaxBodySize: 1048576})
+
+	var traceparent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		b, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `{"card_number":"4111","amount":10}`, string(b))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"PAY_1","token":"secret"}`))
+	}))
+	defer srv.Close()
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    [16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16},
+		SpanID:     [8]byte{1, 2, 3, 4, 5, 6, 7, 8},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	client := &http.Client{Transport: &HTTPClientTransport{
+		LogBody:      true,
+		RedactFields: []string{"card_number", "token"},
+	}}
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL+"/v1/payments?x=1", strings.NewReader(`{"card_number":"4111","amount":10}`))
+	resp, err := client.Do(req)
+	assert.NoError(t, err)
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, `{"id":"PAY_1","token":"secret"}`, string(body))
+	assert.Equal(t, "00-0102030405060708090a0b0c0d0e0f10-0102030405060708-01", traceparent)
+
+	lines := decodeLines(t, buf)
+	assert.Len(t, lines, 1)
+	assert.Equal(t, "client.http", lines[0]["log_type"])
+	assert.Equal(t, "info", lines[0]["level"])
+
+	data := lines[0]["data"].(map[string]any)
+	payload := data["http_client"].(map[string]any)
+	assert.Equal(t, "POST", payload["method"])
+	assert.Equal(t, strings.TrimPrefix(srv.URL, "http://"), payload["host"])
+	assert.Equal(t, "/v1/payments", payload["path"])
+	assert.Equal(t, float64(201), payload["status"])
+	assert.Equal(t, float64(0), payload["retries"])
+	assert.Equal(t, float64(34), payload["request_size"])
+	assert.Equal(t, `{"amount":10,"card_number":"[REDACTED]"}`, payload["request_body"])
+	assert.Equal(t, `{"id":"PAY_1","token":"[REDACTED]"}`, payload["response_body"])
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", data["tracing"].(map[string]any)["trace_id"])
+}
+
+func TestHTTPClientTransport_Retry(t *testing.T) {
+	buf := useBufferLogger(t, config.Config{AppName: "app", MaxBodySize: 4})
+
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "hello world", string(b))
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("done"))
+	}))
+	defer srv.Close()
+
+	// POST is not idempotent, it is only retried with the opt-in.
+	client := &http.Client{Transport: &HTTPClientTransport{LogBody: true, MaxRetries: 3}}
+	resp, err := client.Post(srv.URL, "text/plain", strings.NewReader("hello world"))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	atomic.StoreInt32(&calls, 0)
+	client = &http.Client{Transport: &HTTPClientTransport{LogBody: true, MaxRetries: 3, RetryNonIdempotent: true}}
+	resp, err = client.Post(srv.URL, "text/plain", strings.NewReader("hello world"))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	lines := decodeLines(t, buf)
+	assert.Len(t, lines, 2)
+	assert.Equal(t, float64(0), lines[0]["data"].(map[string]any)["http_client"].(map[string]any)["retries"])
+
+	payload := lines[1]["data"].(map[string]any)["http_client"].(map[string]any)
+	assert.Equal(t, float64(2), payload["retries"])
+	assert.Equal(t, "hell", payload["request_body"])
+}
+
+func TestHTTPClientTransport_LargeBody(t *testing.T) {
+	buf := useBufferLogger(t, config.Config{AppName: "app", MaxBodySize: 8})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(b)
+	}))
+	defer srv.Close()
+
+	// Only MaxBodySize bytes are logged, the server and the caller still get the whole body.
+	big := strings.Repeat("0123456789", 100)
+	client := &http.Client{Transport: &HTTPClientTransport{LogBody: true}}
+	resp, err := client.Post(srv.URL, "text/plain", strings.NewReader(big))
+	assert.NoError(t, err)
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, big, string(body))
+
+	payload := decodeLines(t, buf)[0]["data"].(map[string]any)["http_client"].(map[string]any)
+	assert.Equal(t, "01234567", payload["request_body"])
+	assert.Equal(t, "01234567", payload["response_body"])
+	assert.Equal(t, float64(1000), payload["request_size"])
+
+	// A cut body cannot be parsed to redact its fields, it is replaced as a whole.
+	buf.Reset()
+	client = &http.Client{Transport: &HTTPClientTransport{LogBody: true, RedactFields: []string{"token"}}}
+	resp, err = client.Post(srv.URL, "application/json", strings.NewReader(`{"token":"secret","padding":"`+big+`"}`))
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	payload = decodeLines(t, buf)[0]["data"].(map[string]any)["http_client"].(map[string]any)
+	assert.Equal(t, "[REDACTED]", payload["request_body"])
+	assert.Equal(t, "[REDACTED]", payload["response_body"])
+}
+
+func TestHTTPClientTransport_RedactForm(t *testing.T) {
+	buf := useBufferLogger(t, config.Config{AppName: "app", MaxBodySize: 1048576})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer srv.Close()
+
+	// Form-encoded bodies are not JSON, they cannot be redacted field by field.
+	client := &http.Client{Transport: &HTTPClientTransport{LogBody: true, RedactFields: []string{"password"}}}
+	resp, err := client.PostForm(srv.URL+"/login", url.Values{"user": {"somchai"}, "password": {"secret"}})
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	assert.NotContains(t, buf.String(), "secret")
+
+	payload := decodeLines(t, buf)[0]["data"].(map[string]any)["http_client"].(map[string]any)
+	assert.Equal(t, "[REDACTED]", payload["request_body"])
+	assert.Equal(t, `{"status":"ok"}`, payload["response_body"])
+}
+
+func TestHTTPClientTransport_ReadError(t *testing.T) {
+	buf := useBufferLogger(t, config.Config{AppName: "app"})
+
+	base := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(io.MultiReader(strings.NewReader("par"), errReader{}))}, nil
+	})
+
+	client := &http.Client{Transport: &HTTPClientTransport{Base: base, LogBody: true}}
+	resp, err := client.Get("http://example.com")
+	assert.NoError(t, err)
+
+	_, err = io.ReadAll(resp.Body)
+	assert.EqualError(t, err, "connection reset")
+
+	line := decodeLines(t, buf)[0]
+	assert.Equal(t, "error", line["level"])
+	assert.Equal(t, "HTTPClient response body read failed", line["msg"])
+	assert.Equal(t, "connection reset", line["data"].(map[string]any)["error"])
+}
+
+type roundTripFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestHTTPClientTransport_ServerError(t *testing.T) {
+	buf := useBufferLogger(t, config.Config{AppName: "app"})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: &HTTPClientTransport{}}
+	resp, err := client.Get(srv.URL + "/health")
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	line := decodeLines(t, buf)[0]
+	assert.Equal(t, "error", line["level"])
+	assert.NotContains(t, line["data"].(map[string]any)["http_client"], "response_body")
+}
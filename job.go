@@ -0,0 +1,80 @@
+package slog
+
+import (
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/log"
+	"sync/atomic"
+	"time"
+)
+
+// JobRun tracks the lifecycle of a single job run and builds handler.job logs for it.
+//
+//	run := slog.NewJobRun("sync_orders", "*/5 * * * *", 1)
+//	run.Start().Write()
+//	run.Add(100)
+//	run.Progress().Write()
+//	run.Finish(err).Write()
+type JobRun struct {
+	items    int64 // first field to keep 64-bit alignment for atomic access on 32-bit platforms
+	name     string
+	runID    string
+	schedule string
+	attempt  int64
+	start    time.Time
+}
+
+// NewJobRun creates a run with a random run ID, the clock starts on creation.
+func NewJobRun(name string, schedule string, attempt int64) *JobRun {
+	return &JobRun{
+		name:     name,
+		runID:    randomID(),
+		schedule: schedule,
+		attempt:  attempt,
+		start:    time.Now(),
+	}
+}
+
+// RunID returns the ID shared by every log of this run.
+func (j *JobRun) RunID() string {
+	return j.runID
+}
+
+// Add increments the number of processed items, it is safe for concurrent use.
+func (j *JobRun) Add(n int64) {
+	atomic.AddInt64(&j.items, n)
+}
+
+// Start builds the log marking the beginning of the run.
+func (j *JobRun) Start() log.Log {
+	return Job("Job started", j.payload(log.JobStageStart, ""))
+}
+
+// Progress builds a log reporting the items processed so far.
+func (j *JobRun) Progress() log.Log {
+	return Job("Job in progress", j.payload(log.JobStageProgress, ""))
+}
+
+// Finish builds the log marking the end of the run, a non-nil err marks the run as failed with alert.
+func (j *JobRun) Finish(err error) log.Log {
+	if err != nil {
+		return Job("Job failed", j.payload(log.JobStageFinish, log.JobOutcomeFailure)).
+			SetLevel(level.Error).
+			SetAlert(true).
+			WithError(err)
+	}
+
+	return Job("Job finished", j.payload(log.JobStageFinish, log.JobOutcomeSuccess))
+}
+
+func (j *JobRun) payload(stage log.JobStage, outcome log.JobOutcome) log.JobPayload {
+	return log.JobPayload{
+		Name:           j.name,
+		RunID:          j.runID,
+		Schedule:       j.schedule,
+		Attempt:        j.attempt,
+		Stage:          stage,
+		ItemsProcessed: atomic.LoadInt64(&j.items),
+		Duration:       time.Since(j.start).Seconds(),
+		Outcome:        outcome,
+	}
+}
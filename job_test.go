@@ -0,0 +1,53 @@
+package slog
+
+import (
+	"errors"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/config"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestJobRun(t *testing.T) {
+	buf := useBufferLogger(t, config.Config{AppName: "app"})
+
+	run := NewJobRun("sync_orders", "*/5 * * * *", 2)
+	run.Start().Write()
+	run.Add(40)
+	run.Add(2)
+	run.Progress().Write()
+	run.Finish(nil).Write()
+	run.Finish(errors.New("marketplace unavailable")).Write()
+
+	lines := decodeLines(t, buf)
+	assert.Len(t, lines, 4)
+
+	for i, stage := range []string{"start", "progress", "finish", "finish"} {
+		assert.Equal(t, "handler.job", lines[i]["log_type"])
+
+		job := lines[i]["data"].(map[string]any)["job"].(map[string]any)
+		assert.Equal(t, stage, job["stage"])
+		assert.Equal(t, "sync_orders", job["name"])
+		assert.Equal(t, run.RunID(), job["run_id"])
+		assert.Equal(t, "*/5 * * * *", job["schedule"])
+		assert.Equal(t, float64(2), job["attempt"])
+	}
+
+	start := lines[0]["data"].(map[string]any)["job"].(map[string]any)
+	assert.Equal(t, float64(0), start["items_processed"])
+	assert.NotContains(t, start, "outcome")
+
+	progress := lines[1]["data"].(map[string]any)["job"].(map[string]any)
+	assert.Equal(t, float64(42), progress["items_processed"])
+
+	success := lines[2]
+	assert.Equal(t, "info", success["level"])
+	assert.Equal(t, float64(0), success["alert"])
+	assert.Equal(t, "success", success["data"].(map[string]any)["job"].(map[string]any)["outcome"])
+
+	failure := lines[3]
+	assert.Equal(t, "error", failure["level"])
+	assert.Equal(t, float64(1), failure["alert"])
+	assert.Equal(t, "Job failed", failure["msg"])
+	assert.Equal(t, "marketplace unavailable", failure["data"].(map[string]any)["error"])
+	assert.Equal(t, "failure", failure["data"].(map[string]any)["job"].(map[string]any)["outcome"])
+}
@@ -1,23 +1,122 @@
 package level
 
-import "go.uber.org/zap/zapcore"
+import (
+	"encoding/json"
+	"fmt"
+	"go.uber.org/zap/zapcore"
+	"strconv"
+	"strings"
+)
 
 type Level int8
 
+// Trace and Notice were added in the free slots so the values of the other levels did not change.
+// Notice ranks between Info and Warn although its value is above Error, compare levels with Less rather than by value.
 const (
-	Debug Level = -1
-	Info  Level = 0
-	Warn  Level = 1
-	Error Level = 2
-	Panic Level = 4
-	Fatal Level = 5
+	Trace  Level = -2 // Trace is finer than Debug, it is written as zap debug.
+	Debug  Level = -1
+	Info   Level = 0
+	Warn   Level = 1
+	Error  Level = 2
+	Notice Level = 3 // Notice is a significant but normal event between Info and Warn, it is written as zap info.
+	Panic  Level = 4
+	Fatal  Level = 5
 )
 
+var names = map[Level]string{
+	Trace:  "trace",
+	Debug:  "debug",
+	Info:   "info",
+	Notice: "notice",
+	Warn:   "warn",
+	Error:  "error",
+	Panic:  "panic",
+	Fatal:  "fatal",
+}
+
+// String returns the lower case name of the level, e.g. "warn", or Level(n) for an unknown level.
+func (l Level) String() string {
+	if name, ok := names[l]; ok {
+		return name
+	}
+	return fmt.Sprintf("Level(%d)", int8(l))
+}
+
+// Less reports whether l is less severe than other.
+func (l Level) Less(other Level) bool {
+	return l.rank() < other.rank()
+}
+
+// rank orders the levels by severity, it places Notice between Info and Warn.
+func (l Level) rank() int {
+	if l == Notice {
+		return 1
+	}
+	return int(l) * 2
+}
+
+// Parse returns the level of a case-insensitive name, "warning" is accepted for warn.
+// The numeric value of a level, e.g. "2" for error, is accepted too.
+func Parse(s string) (Level, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	if name == "warning" {
+		name = "warn"
+	}
+
+	if n, err := strconv.ParseInt(name, 10, 8); err == nil {
+		if _, ok := names[Level(n)]; ok {
+			return Level(n), nil
+		}
+	}
+
+	for l, n := range names {
+		if n == name {
+			return l, nil
+		}
+	}
+
+	return Info, fmt.Errorf("unknown level %q, expected trace, debug, info, notice, warn, error, panic or fatal", s)
+}
+
+// MarshalText returns the name of the level, it fails on unknown levels.
+func (l Level) MarshalText() ([]byte, error) {
+	name, ok := names[l]
+	if !ok {
+		return nil, fmt.Errorf("unknown level %d", int8(l))
+	}
+	return []byte(name), nil
+}
+
+// UnmarshalText parses a level name or numeric value, see Parse.
+func (l *Level) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*l = parsed
+	return nil
+}
+
+// UnmarshalJSON accepts a level name or, as written before levels were marshaled as text, its numeric value.
+func (l *Level) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		s = string(b)
+	}
+	return l.UnmarshalText([]byte(s))
+}
+
+// Set parses a level name so a *Level can be used as a flag.Value, e.g. flag.Var(&lvl, "log-level", "minimum level").
+func (l *Level) Set(s string) error {
+	return l.UnmarshalText([]byte(s))
+}
+
+// ToZap returns the zap level an entry is written with, unknown levels are written as info.
 func ToZap(level Level) zapcore.Level {
 	switch level {
-	case Debug:
+	case Trace, Debug:
 		return zapcore.DebugLevel
-	case Info:
+	case Info, Notice:
 		return zapcore.InfoLevel
 	case Warn:
 		return zapcore.WarnLevel
@@ -31,3 +130,42 @@ func ToZap(level Level) zapcore.Level {
 		return zapcore.InfoLevel
 	}
 }
+
+// FromZap returns the level of a zap level, zap dpanic becomes Error and levels below debug become Trace.
+func FromZap(level zapcore.Level) Level {
+	switch {
+	case level < zapcore.DebugLevel:
+		return Trace
+	case level == zapcore.DebugLevel:
+		return Debug
+	case level == zapcore.InfoLevel:
+		return Info
+	case level == zapcore.WarnLevel:
+		return Warn
+	case level <= zapcore.DPanicLevel:
+		return Error
+	case level == zapcore.PanicLevel:
+		return Panic
+	default:
+		return Fatal
+	}
+}
+
+// fieldKey is the key of the Field carrying the level of an entry.
+const fieldKey = "level"
+
+// Field carries l along with the zap entry of a level that does not map back from its zap level, e.g. Notice.
+// Encoders skip it, cores read it back with FromEntry.
+func Field(l Level) zapcore.Field {
+	return zapcore.Field{Key: fieldKey, Type: zapcore.SkipType, Integer: int64(l)}
+}
+
+// FromEntry returns the level carried by Field in fields, or the level of the zap entry level without one.
+func FromEntry(ent zapcore.Entry, fields []zapcore.Field) Level {
+	for _, f := range fields {
+		if f.Type == zapcore.SkipType && f.Key == fieldKey {
+			return Level(f.Integer)
+		}
+	}
+	return FromZap(ent.Level)
+}
@@ -1,6 +1,9 @@
 package level
 
 import (
+	"encoding/json"
+	"flag"
+	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap/zapcore"
 	"testing"
 )
@@ -21,6 +24,13 @@ func TestToZap(t *testing.T) {
 			},
 			want: zapcore.DebugLevel,
 		},
+		{
+			name: "Trace",
+			args: args{
+				level: Trace,
+			},
+			want: zapcore.DebugLevel,
+		},
 		{
 			name: "Info",
 			args: args{
@@ -28,6 +38,13 @@ func TestToZap(t *testing.T) {
 			},
 			want: zapcore.InfoLevel,
 		},
+		{
+			name: "Notice",
+			args: args{
+				level: Notice,
+			},
+			want: zapcore.InfoLevel,
+		},
 		{
 			name: "Warn",
 			args: args{
@@ -73,3 +90,115 @@ func TestToZap(t *testing.T) {
 		})
 	}
 }
+
+func TestParse(t *testing.T) {
+	for name, want := range map[string]Level{
+		"trace":   Trace,
+		"debug":   Debug,
+		"INFO":    Info,
+		"notice":  Notice,
+		" warn ":  Warn,
+		"Warning": Warn,
+		"error":   Error,
+		"panic":   Panic,
+		"fatal":   Fatal,
+	} {
+		got, err := Parse(name)
+		assert.NoError(t, err, name)
+		assert.Equal(t, want, got, name)
+	}
+
+	got, err := Parse("2")
+	assert.NoError(t, err)
+	assert.Equal(t, Error, got)
+
+	_, err = Parse("verbose")
+	assert.EqualError(t, err, `unknown level "verbose", expected trace, debug, info, notice, warn, error, panic or fatal`)
+}
+
+func TestLevel_Values(t *testing.T) {
+	// The values of the levels predating Trace and Notice must not change, they are cast and persisted as numbers.
+	assert.Equal(t, []Level{-1, 0, 1, 2, 4, 5}, []Level{Debug, Info, Warn, Error, Panic, Fatal})
+}
+
+func TestLevel_Less(t *testing.T) {
+	ordered := []Level{Trace, Debug, Info, Notice, Warn, Error, Panic, Fatal}
+	for i := range ordered {
+		for j := range ordered {
+			assert.Equal(t, i < j, ordered[i].Less(ordered[j]), "%s < %s", ordered[i], ordered[j])
+		}
+	}
+}
+
+func TestLevel_String(t *testing.T) {
+	assert.Equal(t, "notice", Notice.String())
+	assert.Equal(t, "Level(7)", Level(7).String())
+}
+
+func TestLevel_Text(t *testing.T) {
+	var cfg struct {
+		Level Level `json:"level"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(`{"level":"trace"}`), &cfg))
+	assert.Equal(t, Trace, cfg.Level)
+
+	b, err := json.Marshal(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"level":"trace"}`, string(b))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"level":"verbose"}`), &cfg))
+
+	_, err = Level(7).MarshalText()
+	assert.EqualError(t, err, "unknown level 7")
+
+	// Levels written as numbers keep their meaning.
+	assert.NoError(t, json.Unmarshal([]byte(`{"level":2}`), &cfg))
+	assert.Equal(t, Error, cfg.Level)
+	assert.NoError(t, json.Unmarshal([]byte(`{"level":"-1"}`), &cfg))
+	assert.Equal(t, Debug, cfg.Level)
+	assert.Error(t, json.Unmarshal([]byte(`{"level":7}`), &cfg))
+}
+
+func TestLevel_Flag(t *testing.T) {
+	lvl := Info
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(&lvl, "log-level", "minimum level")
+
+	assert.NoError(t, fs.Parse([]string{"-log-level", "warn"}))
+	assert.Equal(t, Warn, lvl)
+	assert.Equal(t, "warn", fs.Lookup("log-level").Value.String())
+
+	assert.Error(t, fs.Parse([]string{"-log-level", "verbose"}))
+}
+
+func TestFromZap(t *testing.T) {
+	for zl, want := range map[zapcore.Level]Level{
+		zapcore.DebugLevel - 1: Trace,
+		zapcore.DebugLevel:     Debug,
+		zapcore.InfoLevel:      Info,
+		zapcore.WarnLevel:      Warn,
+		zapcore.ErrorLevel:     Error,
+		zapcore.DPanicLevel:    Error,
+		zapcore.PanicLevel:     Panic,
+		zapcore.FatalLevel:     Fatal,
+	} {
+		assert.Equal(t, want, FromZap(zl), zl.String())
+	}
+
+	for l := range names {
+		if l != Trace && l != Notice {
+			assert.Equal(t, l, FromZap(ToZap(l)), l.String())
+		}
+	}
+}
+
+func TestFromEntry(t *testing.T) {
+	ent := zapcore.Entry{Level: zapcore.InfoLevel}
+	assert.Equal(t, Info, FromEntry(ent, nil))
+	assert.Equal(t, Notice, FromEntry(ent, []zapcore.Field{{Key: "level", Type: zapcore.StringType, String: "warn"}, Field(Notice)}))
+
+	// The field is skipped by encoders.
+	enc := zapcore.NewMapObjectEncoder()
+	Field(Notice).AddTo(enc)
+	assert.Empty(t, enc.Fields)
+}
@@ -0,0 +1,21 @@
+package log
+
+type AlertSeverity string
+
+// AlertInfo describes how an alert entry should be routed, it is written next to the alert flag.
+type AlertInfo struct {
+	Severity   AlertSeverity `json:"severity,omitempty"`    // Severity is the urgency of the alert, such as AlertSeverityP1 or AlertSeverityCritical.
+	Team       string        `json:"team,omitempty"`        // Team is the owning team or routing key, e.g. "payments".
+	RunbookURL string        `json:"runbook_url,omitempty"` // RunbookURL links to the steps to handle the alert.
+}
+
+const (
+	AlertSeverityP1 AlertSeverity = "p1"
+	AlertSeverityP2 AlertSeverity = "p2"
+	AlertSeverityP3 AlertSeverity = "p3"
+	AlertSeverityP4 AlertSeverity = "p4"
+
+	AlertSeverityCritical AlertSeverity = "critical"
+	AlertSeverityHigh     AlertSeverity = "high"
+	AlertSeverityLow      AlertSeverity = "low"
+)
@@ -0,0 +1,13 @@
+package log
+
+// DBQueryPayload represents a single SQL statement executed through the database/sql driver wrapper.
+type DBQueryPayload struct {
+	Operation    string   `json:"operation"`       // Operation is the kind of statement call, "exec" or "query".
+	Query        string   `json:"query"`           // Query is the SQL text as sent to the driver.
+	ArgCount     int      `json:"arg_count"`       // ArgCount is the number of bound arguments.
+	Args         []string `json:"args,omitempty"`  // Args holds the bound argument values, only when argument logging is enabled.
+	RowsAffected int64    `json:"rows_affected"`   // RowsAffected is the number of rows affected by an exec, -1 if unknown.
+	Duration     float64  `json:"duration"`        // Duration is the time the driver took to run the statement in seconds.
+	TxID         string   `json:"tx_id,omitempty"` // TxID identifies the transaction the statement ran in, empty outside a transaction.
+	Slow         bool     `json:"slow,omitempty"`  // Slow is true when Duration exceeded the configured slow query threshold.
+}
@@ -0,0 +1,15 @@
+package log
+
+// HTTPClientPayload represents an outgoing HTTP call made by the application (e.g. to a payment gateway).
+type HTTPClientPayload struct {
+	Method       string  `json:"method"`                  // The HTTP method of the request (e.g., "GET", "POST").
+	Host         string  `json:"host"`                    // The host of the remote service.
+	Path         string  `json:"path"`                    // The path of the request URL, without the query string.
+	Status       int64   `json:"status"`                  // The HTTP status code of the response, 0 if no response was received.
+	Duration     float64 `json:"duration"`                // The duration of the call including retries in seconds.
+	Retries      int64   `json:"retries"`                 // The number of retries performed after the first attempt.
+	RequestSize  int64   `json:"request_size"`            // The size of the request body in bytes, -1 if unknown.
+	ResponseSize int64   `json:"response_size"`           // The size of the response body in bytes, -1 if unknown.
+	RequestBody  string  `json:"request_body,omitempty"`  // The request body, only when body logging is enabled.
+	ResponseBody string  `json:"response_body,omitempty"` // The response body, only when body logging is enabled.
+}
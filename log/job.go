@@ -0,0 +1,25 @@
+package log
+
+type JobStage string
+type JobOutcome string
+
+// JobPayload holds information about a single run of a background or scheduled job.
+type JobPayload struct {
+	Name           string     `json:"name"`              // Name is the name of the job, e.g. "sync_marketplace_orders".
+	RunID          string     `json:"run_id"`            // RunID uniquely identifies this run of the job.
+	Schedule       string     `json:"schedule"`          // Schedule is the cron expression or interval that triggered the run, empty for ad-hoc runs.
+	Attempt        int64      `json:"attempt"`           // Attempt is the attempt number of this run, starting at 1.
+	Stage          JobStage   `json:"stage"`             // Stage is the lifecycle stage of the run, such as JobStageStart or JobStageFinish.
+	ItemsProcessed int64      `json:"items_processed"`   // ItemsProcessed is the number of items processed so far.
+	Duration       float64    `json:"duration"`          // Duration is the time elapsed since the run started in seconds.
+	Outcome        JobOutcome `json:"outcome,omitempty"` // Outcome is the result of the run, only set when the run has finished.
+}
+
+const (
+	JobStageStart    JobStage = "start"
+	JobStageProgress JobStage = "progress"
+	JobStageFinish   JobStage = "finish"
+
+	JobOutcomeSuccess JobOutcome = "success"
+	JobOutcomeFailure JobOutcome = "failure"
+)
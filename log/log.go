@@ -4,18 +4,25 @@ import (
 	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap/zapcore"
+	"time"
 )
 
+// Log builds an entry, see the README for the methods added in v2 that implementations outside this module must add.
 type Log interface {
 	Write()                                    // Logs the current entry to the output.
 	SetMessage(msg string) Log                 // Sets or overrides the log message.
 	SetLevel(level level.Level) Log            // Sets or overrides the log level (e.g., info, warning, error).
-	SetAlert(bool bool) Log                    // Sets or overrides the alert flag.
+	SetAlert(bool bool, info ...AlertInfo) Log // Sets or overrides the alert flag, optionally with its severity, team and runbook.
 	WithAppData(key string, value any) Log     // Adds application-specific data.
 	WithError(err error) Log                   // Adds error information.
 	WithTracing(t trace.SpanContext) Log       // Adds tracing information.
 	WithStackTrace() Log                       // Captures and adds a stack trace.
 	WithAppJsonData(key string, value any) Log // Set arbitrary json data
+	Once() Log                                 // Writes the entry only once per call site or limit key.
+	EveryN(n int) Log                          // Writes the first entry and then every nth one per call site or limit key.
+	RateLimit(n int, per time.Duration) Log    // Writes at most n entries per interval per call site or limit key.
+	LimitKey(key string) Log                   // Counts Once, EveryN and RateLimit by key instead of by call site.
+	CallerSkip(n int) Log                      // Reports the caller n more frames up the stack.
 }
 
 type ZapLogger interface {
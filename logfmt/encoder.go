@@ -0,0 +1,47 @@
+package logfmt
+
+import (
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+var bufferPool = buffer.NewPool()
+
+// encoder converts the output of an embedded JSON encoder into logfmt.
+type encoder struct {
+	zapcore.Encoder
+	lineEnding string
+}
+
+// NewEncoder returns a zapcore.Encoder writing entries as logfmt, keys and value encoders are taken from cfg.
+func NewEncoder(cfg zapcore.EncoderConfig) zapcore.Encoder {
+	lineEnding := cfg.LineEnding
+	if lineEnding == "" {
+		lineEnding = zapcore.DefaultLineEnding
+	}
+
+	return &encoder{Encoder: zapcore.NewJSONEncoder(cfg), lineEnding: lineEnding}
+}
+
+func (e *encoder) Clone() zapcore.Encoder {
+	return &encoder{Encoder: e.Encoder.Clone(), lineEnding: e.lineEnding}
+}
+
+func (e *encoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	line, err := e.Encoder.EncodeEntry(ent, fields)
+	if err != nil {
+		return nil, err
+	}
+	defer line.Free()
+
+	b, err := Marshal(line.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	out := bufferPool.Get()
+	out.Write(b)
+	out.AppendString(e.lineEnding)
+
+	return out, nil
+}
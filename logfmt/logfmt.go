@@ -0,0 +1,113 @@
+package logfmt
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Marshal converts a JSON object into a logfmt line without line ending, e.g.
+// {"level":"info","data":{"http_request":{"method":"POST"}}} becomes level=info data.http_request.method=POST.
+// Nested objects and arrays are flattened into dotted keys and keep the order of the JSON input.
+func Marshal(jsonObject []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(jsonObject))
+	dec.UseNumber()
+
+	w := &writer{}
+	if err := w.value(dec, ""); err != nil {
+		return nil, err
+	}
+
+	return w.b.Bytes(), nil
+}
+
+type writer struct {
+	b bytes.Buffer
+}
+
+func (w *writer) value(dec *json.Decoder, key string) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		n := 0
+		for ; dec.More(); n++ {
+			childKey := strconv.Itoa(n)
+			if t == '{' {
+				k, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				childKey = k.(string)
+			}
+			if err := w.value(dec, join(key, childKey)); err != nil {
+				return err
+			}
+		}
+
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+
+		if n == 0 && key != "" {
+			if t == '{' {
+				w.pair(key, "{}")
+			} else {
+				w.pair(key, "[]")
+			}
+		}
+	case string:
+		w.pair(key, quote(t))
+	case json.Number:
+		w.pair(key, t.String())
+	case bool:
+		w.pair(key, strconv.FormatBool(t))
+	case nil:
+		w.pair(key, "null")
+	}
+
+	return nil
+}
+
+func (w *writer) pair(key string, value string) {
+	if w.b.Len() > 0 {
+		w.b.WriteByte(' ')
+	}
+	w.b.WriteString(key)
+	w.b.WriteByte('=')
+	w.b.WriteString(value)
+}
+
+func join(prefix string, key string) string {
+	key = strings.Map(func(r rune) rune {
+		if r == '=' || r == '"' || unicode.IsSpace(r) || !unicode.IsPrint(r) {
+			return '_'
+		}
+		return r
+	}, key)
+
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// quote returns s unchanged when it is safe as a bare logfmt value, otherwise a quoted and escaped string.
+func quote(s string) string {
+	if s == "" {
+		return `""`
+	}
+
+	for _, r := range s {
+		if r == '=' || r == '"' || r == '\\' || unicode.IsSpace(r) || !unicode.IsPrint(r) {
+			return strconv.Quote(s)
+		}
+	}
+
+	return s
+}
@@ -0,0 +1,69 @@
+package logfmt
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"testing"
+	"time"
+)
+
+func TestMarshal(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{
+			name: "Flat values",
+			json: `{"level":"info","alert":0,"ok":true,"missing":null,"duration":0.25}`,
+			want: `level=info alert=0 ok=true missing=null duration=0.25`,
+		},
+		{
+			name: "Nested objects keep input order",
+			json: `{"message":"m","data":{"http_request":{"method":"POST","path":"/v1/orders"},"error":"boom"}}`,
+			want: `message=m data.http_request.method=POST data.http_request.path=/v1/orders data.error=boom`,
+		},
+		{
+			name: "Arrays and empty containers",
+			json: `{"refs":["a","b"],"headers":{},"list":[]}`,
+			want: `refs.0=a refs.1=b headers={} list=[]`,
+		},
+		{
+			name: "Quoting and escaping",
+			json: `{"message":"two words","body":"{\"key\": \"value\"}","empty":"","path":"C:\\tmp","multi":"a\nb","thai":"สวัสดี"}`,
+			want: `message="two words" body="{\"key\": \"value\"}" empty="" path="C:\\tmp" multi="a\nb" thai=สวัสดี`,
+		},
+		{
+			name: "Unsafe keys",
+			json: `{"app":{"user id":1,"a=b":2}}`,
+			want: `app.user_id=1 app.a_b=2`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Marshal([]byte(tt.json))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+
+	_, err := Marshal([]byte(`{"broken":`))
+	assert.Error(t, err)
+}
+
+func TestEncoder(t *testing.T) {
+	var buf bytes.Buffer
+
+	cfg := zap.NewProductionEncoderConfig()
+	cfg.EncodeTime = func(_ time.Time, enc zapcore.PrimitiveArrayEncoder) {
+		enc.AppendString("fixed")
+	}
+
+	logger := zap.New(zapcore.NewCore(NewEncoder(cfg), zapcore.AddSync(&buf), zap.DebugLevel))
+	logger.Info("Info message", zap.String("log_type", "application"), zap.Any("data", map[string]any{"error": "not found"}))
+
+	assert.Equal(t, "level=info ts=fixed msg=\"Info message\" log_type=application data.error=\"not found\"\n", buf.String())
+}
@@ -0,0 +1,63 @@
+package logid
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"time"
+)
+
+// Generator returns a new unique ID for an entry written at t.
+type Generator func(t time.Time) string
+
+// crockford is the Crockford base32 alphabet used by ULIDs.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ULID returns a ULID, 26 upper case characters sorting by t to the millisecond followed by 80 random bits,
+// e.g. 01HEW5Z6ZK8ZV1N4J3T1R8W2QX.
+func ULID(t time.Time) string {
+	var b [16]byte
+	putMillis(b[:6], t)
+	_, _ = rand.Read(b[6:])
+
+	// 128 bits are written as 26 characters of 5 bits, the first character holds the 3 highest bits.
+	hi, lo := binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])
+	var out [26]byte
+	for i := 25; i >= 0; i-- {
+		out[i] = crockford[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
+
+// UUIDv7 returns a version 7 UUID as defined by RFC 9562, sorting by t to the millisecond,
+// e.g. 018bb25f-9bf3-7c4d-9a1e-2b5c0f7d3e81.
+func UUIDv7(t time.Time) string {
+	var b [16]byte
+	putMillis(b[:6], t)
+	_, _ = rand.Read(b[6:])
+	b[6] = b[6]&0x0f | 0x70 // version 7
+	b[8] = b[8]&0x3f | 0x80 // variant 10
+
+	var out [36]byte
+	hex.Encode(out[0:8], b[0:4])
+	out[8] = '-'
+	hex.Encode(out[9:13], b[4:6])
+	out[13] = '-'
+	hex.Encode(out[14:18], b[6:8])
+	out[18] = '-'
+	hex.Encode(out[19:23], b[8:10])
+	out[23] = '-'
+	hex.Encode(out[24:], b[10:])
+	return string(out[:])
+}
+
+// putMillis writes the milliseconds since the Unix epoch as a 48 bit big endian integer.
+func putMillis(b []byte, t time.Time) {
+	ms := uint64(t.UnixNano() / int64(time.Millisecond))
+	for i := 5; i >= 0; i-- {
+		b[i] = byte(ms)
+		ms >>= 8
+	}
+}
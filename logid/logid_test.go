@@ -0,0 +1,47 @@
+package logid
+
+import (
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"sort"
+	"testing"
+	"time"
+)
+
+var (
+	ulidPattern   = regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`)
+	uuidv7Pattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+)
+
+func TestULID(t *testing.T) {
+	ts := time.Date(2023, 11, 9, 7, 48, 14, 803000000, time.UTC)
+
+	id := ULID(ts)
+	assert.Regexp(t, ulidPattern, id)
+	// 1699516094803 ms in Crockford base32.
+	assert.Equal(t, "01HESGV6AK", id[:10])
+	assert.NotEqual(t, id, ULID(ts))
+
+	assert.Equal(t, "0000000000", ULID(time.UnixMilli(0))[:10])
+}
+
+func TestUUIDv7(t *testing.T) {
+	ts := time.Date(2023, 11, 9, 7, 48, 14, 803000000, time.UTC)
+
+	id := UUIDv7(ts)
+	assert.Regexp(t, uuidv7Pattern, id)
+	assert.Equal(t, "018bb30d-9953", id[:13])
+	assert.NotEqual(t, id, UUIDv7(ts))
+}
+
+func TestSortable(t *testing.T) {
+	for _, generate := range []Generator{ULID, UUIDv7} {
+		ts := time.Date(2023, 11, 9, 7, 48, 14, 0, time.UTC)
+
+		var ids []string
+		for i := 0; i < 100; i++ {
+			ids = append(ids, generate(ts.Add(time.Duration(i)*time.Millisecond)))
+		}
+		assert.True(t, sort.StringsAreSorted(ids))
+	}
+}
@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/zap_logger"
+	"go.uber.org/zap/zapcore"
+)
+
+// Core wraps a zapcore.Core and counts the entries it writes by level, log_type and alert.
+// Wrap the core below the sampler so sampled out entries are not counted as written.
+func (m *Metrics) Core(c zapcore.Core) zapcore.Core {
+	return &core{Core: c, metrics: m}
+}
+
+// SamplerHook counts the entries dropped by a zapcore sampler, see zapcore.SamplerHook.
+// The sampler does not pass the fields of an entry, so Trace and Notice entries are counted as debug and info.
+func (m *Metrics) SamplerHook(ent zapcore.Entry, dec zapcore.SamplingDecision) {
+	if dec&zapcore.LogDropped != 0 {
+		m.AddSampledOut(level.FromZap(ent.Level))
+	}
+}
+
+// WriteSyncer wraps ws and counts the bytes written to it.
+func (m *Metrics) WriteSyncer(ws zapcore.WriteSyncer) zapcore.WriteSyncer {
+	return &writeSyncer{WriteSyncer: ws, metrics: m}
+}
+
+type core struct {
+	zapcore.Core
+	metrics *Metrics
+}
+
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	return &core{Core: c.Core.With(fields), metrics: c.metrics}
+}
+
+func (c *core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if err := c.Core.Write(ent, fields); err != nil {
+		c.metrics.AddWriteError()
+		return err
+	}
+
+	k := Key{Level: level.FromEntry(ent, fields)}
+	for _, f := range fields {
+		switch {
+		case f.Key == "log_type" && f.Type == zapcore.StringType:
+			k.Type = zap_logger.Type(f.String)
+		case f.Key == "alert" && f.Type == zapcore.Int64Type:
+			k.Alert = f.Integer == 1
+		}
+	}
+	c.metrics.AddEntry(k)
+
+	return nil
+}
+
+type writeSyncer struct {
+	zapcore.WriteSyncer
+	metrics *Metrics
+}
+
+func (w *writeSyncer) Write(p []byte) (int, error) {
+	n, err := w.WriteSyncer.Write(p)
+	w.metrics.AddBytesWritten(n)
+	return n, err
+}
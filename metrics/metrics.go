@@ -0,0 +1,192 @@
+package metrics
+
+import (
+	"expvar"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/zap_logger"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// Default collects the metrics of the logger created by slog.Init, it is published to expvar as "sellsuki_logger".
+var Default = New()
+
+func init() {
+	expvar.Publish("sellsuki_logger", expvar.Func(func() any {
+		return Default.Snapshot()
+	}))
+}
+
+// Key identifies a group of written entries.
+type Key struct {
+	Level level.Level
+	Type  zap_logger.Type
+	Alert bool
+}
+
+// Metrics holds the logger counters, it is safe for concurrent use.
+type Metrics struct {
+	bytesWritten uint64
+	writeErrors  uint64
+
+	mu         sync.RWMutex
+	entries    map[Key]*uint64
+	sampledOut map[level.Level]*uint64
+	dropped    map[string]*uint64
+}
+
+// Snapshot is a point in time copy of the counters.
+type Snapshot struct {
+	Entries      []EntryCount `json:"entries"`
+	BytesWritten uint64       `json:"bytes_written"`
+	SampledOut   []LevelCount `json:"sampled_out"`
+	WriteErrors  uint64       `json:"write_errors"`
+	Dropped      []SinkCount  `json:"dropped"`
+}
+
+// EntryCount is the number of entries written for a key.
+type EntryCount struct {
+	Level string          `json:"level"`
+	Type  zap_logger.Type `json:"log_type"`
+	Alert bool            `json:"alert"`
+	Count uint64          `json:"count"`
+}
+
+// LevelCount is the number of entries of a level.
+type LevelCount struct {
+	Level string `json:"level"`
+	Count uint64 `json:"count"`
+}
+
+// SinkCount is the number of entries dropped by a sink.
+type SinkCount struct {
+	Sink  string `json:"sink"`
+	Count uint64 `json:"count"`
+}
+
+func New() *Metrics {
+	return &Metrics{
+		entries:    map[Key]*uint64{},
+		sampledOut: map[level.Level]*uint64{},
+		dropped:    map[string]*uint64{},
+	}
+}
+
+// AddEntry counts a written entry.
+func (m *Metrics) AddEntry(k Key) {
+	m.mu.RLock()
+	c, ok := m.entries[k]
+	m.mu.RUnlock()
+
+	if !ok {
+		m.mu.Lock()
+		if c, ok = m.entries[k]; !ok {
+			c = new(uint64)
+			m.entries[k] = c
+		}
+		m.mu.Unlock()
+	}
+
+	atomic.AddUint64(c, 1)
+}
+
+// AddSampledOut counts an entry dropped by the sampler.
+func (m *Metrics) AddSampledOut(l level.Level) {
+	m.mu.RLock()
+	c, ok := m.sampledOut[l]
+	m.mu.RUnlock()
+
+	if !ok {
+		m.mu.Lock()
+		if c, ok = m.sampledOut[l]; !ok {
+			c = new(uint64)
+			m.sampledOut[l] = c
+		}
+		m.mu.Unlock()
+	}
+
+	atomic.AddUint64(c, 1)
+}
+
+// AddDropped counts n entries dropped by a sink, e.g. "otlp" when its queue is full or "alert" over the rate limit.
+func (m *Metrics) AddDropped(sink string, n int) {
+	m.mu.RLock()
+	c, ok := m.dropped[sink]
+	m.mu.RUnlock()
+
+	if !ok {
+		m.mu.Lock()
+		if c, ok = m.dropped[sink]; !ok {
+			c = new(uint64)
+			m.dropped[sink] = c
+		}
+		m.mu.Unlock()
+	}
+
+	atomic.AddUint64(c, uint64(n))
+}
+
+// AddBytesWritten counts bytes written to the output.
+func (m *Metrics) AddBytesWritten(n int) {
+	atomic.AddUint64(&m.bytesWritten, uint64(n))
+}
+
+// AddWriteError counts an entry that failed to be encoded or written.
+func (m *Metrics) AddWriteError() {
+	atomic.AddUint64(&m.writeErrors, 1)
+}
+
+// Snapshot returns the current counters sorted by level, log type and alert.
+func (m *Metrics) Snapshot() Snapshot {
+	s := Snapshot{
+		Entries:      []EntryCount{},
+		SampledOut:   []LevelCount{},
+		Dropped:      []SinkCount{},
+		BytesWritten: atomic.LoadUint64(&m.bytesWritten),
+		WriteErrors:  atomic.LoadUint64(&m.writeErrors),
+	}
+
+	m.mu.RLock()
+	keys := make([]Key, 0, len(m.entries))
+	for k := range m.entries {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Level != keys[j].Level {
+			return keys[i].Level.Less(keys[j].Level)
+		}
+		if keys[i].Type != keys[j].Type {
+			return keys[i].Type < keys[j].Type
+		}
+		return !keys[i].Alert && keys[j].Alert
+	})
+	for _, k := range keys {
+		s.Entries = append(s.Entries, EntryCount{Level: k.Level.String(), Type: k.Type, Alert: k.Alert, Count: atomic.LoadUint64(m.entries[k])})
+	}
+
+	levels := make([]level.Level, 0, len(m.sampledOut))
+	for l := range m.sampledOut {
+		levels = append(levels, l)
+	}
+	sort.Slice(levels, func(i, j int) bool { return levels[i].Less(levels[j]) })
+	for _, l := range levels {
+		s.SampledOut = append(s.SampledOut, LevelCount{Level: l.String(), Count: atomic.LoadUint64(m.sampledOut[l])})
+	}
+
+	for _, sink := range sortedKeys(m.dropped) {
+		s.Dropped = append(s.Dropped, SinkCount{Sink: sink, Count: atomic.LoadUint64(m.dropped[sink])})
+	}
+	m.mu.RUnlock()
+
+	return s
+}
+
+func sortedKeys(m map[string]*uint64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
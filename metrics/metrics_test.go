@@ -0,0 +1,127 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/zap_logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+func (failingWriter) Sync() error               { return nil }
+
+func newTestLogger(m *Metrics, ws zapcore.WriteSyncer) *zap.Logger {
+	enc := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	c := zapcore.NewCore(enc, m.WriteSyncer(ws), zapcore.DebugLevel)
+	return zap.New(zapcore.NewSamplerWithOptions(m.Core(c), time.Hour, 2, 0, zapcore.SamplerHook(m.SamplerHook)))
+}
+
+func TestMetrics(t *testing.T) {
+	m := New()
+	buf := &bytes.Buffer{}
+	logger := newTestLogger(m, zapcore.AddSync(buf))
+
+	for i := 0; i < 3; i++ {
+		logger.Info("created", zap.Int("alert", 0), zap.String("log_type", "application"))
+	}
+	logger.Error("failed", zap.Int("alert", 1), zap.String("log_type", "handler.http"))
+	logger.Error("failed again", zap.Int("alert", 0), zap.String("log_type", "handler.http"))
+
+	assert.Equal(t, Snapshot{
+		Entries: []EntryCount{
+			{Level: "info", Type: "application", Count: 2},
+			{Level: "error", Type: "handler.http", Count: 1},
+			{Level: "error", Type: "handler.http", Alert: true, Count: 1},
+		},
+		BytesWritten: uint64(buf.Len()),
+		SampledOut:   []LevelCount{{Level: "info", Count: 1}},
+		Dropped:      []SinkCount{},
+	}, m.Snapshot())
+}
+
+func TestMetrics_Level(t *testing.T) {
+	m := New()
+	logger := zap.New(m.Core(zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(&bytes.Buffer{}), zapcore.DebugLevel)))
+
+	// Notice and Trace are written as zap info and debug, their level is carried by level.Field.
+	logger.Info("notice", zap.String("log_type", "application"), level.Field(level.Notice))
+	logger.Debug("trace", zap.String("log_type", "application"), level.Field(level.Trace))
+
+	assert.Equal(t, []EntryCount{
+		{Level: "trace", Type: "application", Count: 1},
+		{Level: "notice", Type: "application", Count: 1},
+	}, m.Snapshot().Entries)
+}
+
+func TestMetrics_WriteErrors(t *testing.T) {
+	m := New()
+	logger := newTestLogger(m, failingWriter{})
+
+	logger.Info("lost", zap.String("log_type", "application"))
+
+	s := m.Snapshot()
+	assert.Equal(t, uint64(1), s.WriteErrors)
+	assert.Empty(t, s.Entries)
+}
+
+func TestHandler(t *testing.T) {
+	m := New()
+	m.AddEntry(Key{Level: level.Warn, Type: `odd"type`, Alert: true})
+	m.AddEntry(Key{Level: level.Debug, Type: zap_logger.TypeApplication})
+	m.AddEntry(Key{Level: level.Debug, Type: zap_logger.TypeApplication})
+	m.AddSampledOut(level.Debug)
+	m.AddBytesWritten(10)
+	m.AddDropped("otlp", 2)
+	m.AddDropped("alert", 1)
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `# HELP sellsuki_logger_entries_total Log entries written by level, log type and alert.
+# TYPE sellsuki_logger_entries_total counter
+sellsuki_logger_entries_total{level="debug",log_type="application",alert="0"} 2
+sellsuki_logger_entries_total{level="warn",log_type="odd\"type",alert="1"} 1
+# HELP sellsuki_logger_bytes_written_total Bytes written to the log output.
+# TYPE sellsuki_logger_bytes_written_total counter
+sellsuki_logger_bytes_written_total 10
+# HELP sellsuki_logger_sampled_out_total Log entries dropped by the sampler by level.
+# TYPE sellsuki_logger_sampled_out_total counter
+sellsuki_logger_sampled_out_total{level="debug"} 1
+# HELP sellsuki_logger_write_errors_total Log entries that failed to be encoded or written.
+# TYPE sellsuki_logger_write_errors_total counter
+sellsuki_logger_write_errors_total 0
+# HELP sellsuki_logger_dropped_total Log entries dropped by a sink, e.g. over its queue size or rate limit.
+# TYPE sellsuki_logger_dropped_total counter
+sellsuki_logger_dropped_total{sink="alert"} 1
+sellsuki_logger_dropped_total{sink="otlp"} 2
+`, rec.Body.String())
+}
+
+func TestExpvar(t *testing.T) {
+	// Default is shared by the whole process, compare the count before and after.
+	count := func() uint64 {
+		s := Snapshot{}
+		assert.NoError(t, json.Unmarshal([]byte(expvar.Get("sellsuki_logger").String()), &s))
+		for _, e := range s.Entries {
+			if e.Level == "info" && e.Type == "application" && !e.Alert {
+				return e.Count
+			}
+		}
+		return 0
+	}
+
+	before := count()
+	Default.AddEntry(Key{Type: "application"})
+	assert.Equal(t, before+1, count())
+}
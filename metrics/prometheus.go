@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Handler serves the counters in the Prometheus text exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = m.WritePrometheus(w)
+	})
+}
+
+// Handler serves the Default counters in the Prometheus text exposition format.
+func Handler() http.Handler {
+	return Default.Handler()
+}
+
+// WritePrometheus writes the counters in the Prometheus text exposition format.
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	s := m.Snapshot()
+	b := &strings.Builder{}
+
+	header(b, "sellsuki_logger_entries_total", "Log entries written by level, log type and alert.")
+	for _, e := range s.Entries {
+		alert := "0"
+		if e.Alert {
+			alert = "1"
+		}
+		fmt.Fprintf(b, "sellsuki_logger_entries_total{level=%s,log_type=%s,alert=%s} %d\n",
+			label(e.Level), label(string(e.Type)), label(alert), e.Count)
+	}
+
+	header(b, "sellsuki_logger_bytes_written_total", "Bytes written to the log output.")
+	fmt.Fprintf(b, "sellsuki_logger_bytes_written_total %d\n", s.BytesWritten)
+
+	header(b, "sellsuki_logger_sampled_out_total", "Log entries dropped by the sampler by level.")
+	for _, l := range s.SampledOut {
+		fmt.Fprintf(b, "sellsuki_logger_sampled_out_total{level=%s} %d\n", label(l.Level), l.Count)
+	}
+
+	header(b, "sellsuki_logger_write_errors_total", "Log entries that failed to be encoded or written.")
+	fmt.Fprintf(b, "sellsuki_logger_write_errors_total %d\n", s.WriteErrors)
+
+	header(b, "sellsuki_logger_dropped_total", "Log entries dropped by a sink, e.g. over its queue size or rate limit.")
+	for _, d := range s.Dropped {
+		fmt.Fprintf(b, "sellsuki_logger_dropped_total{sink=%s} %d\n", label(d.Sink), d.Count)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func header(b *strings.Builder, name, help string) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func label(v string) string {
+	return `"` + labelEscaper.Replace(v) + `"`
+}
@@ -0,0 +1,142 @@
+package slog
+
+import (
+	"github.com/Sellsuki/sellsuki-go-logger/v2/config"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/logid"
+	"time"
+)
+
+// Option changes the configuration built by Init.
+// A config.Config is an Option too, it replaces the whole configuration built so far, see config.Config.Apply.
+type Option interface {
+	Apply(c *config.Config)
+}
+
+type optionFunc func(c *config.Config)
+
+func (f optionFunc) Apply(c *config.Config) {
+	f(c)
+}
+
+// NewProductionConfig returns the defaults with JSON output and sampling, the configuration used by Init without options.
+func NewProductionConfig() config.Config {
+	c := config.Default()
+	c.Encoding = config.EncodingJSON
+	return c
+}
+
+// NewDevelopmentConfig returns the defaults with debug level, human-readable output and sampling disabled.
+func NewDevelopmentConfig() config.Config {
+	c := config.Default()
+	c.LogLevel = level.Debug
+	c.Readable = true
+	c.Encoding = config.EncodingConsole
+	c.Sampling.Disabled = true
+	return c
+}
+
+// newConfig applies the options in order over the defaults.
+func newConfig(opts ...Option) config.Config {
+	c := config.Default()
+	for _, opt := range opts {
+		if opt != nil {
+			opt.Apply(&c)
+		}
+	}
+	return c
+}
+
+func WithLevel(l level.Level) Option {
+	return optionFunc(func(c *config.Config) { c.LogLevel = l })
+}
+
+func WithAppName(name string) Option {
+	return optionFunc(func(c *config.Config) { c.AppName = name })
+}
+
+func WithVersion(version string) Option {
+	return optionFunc(func(c *config.Config) { c.Version = version })
+}
+
+// WithMaxBodySize sets the max size of logged request and response bodies in bytes, 0 means unlimited.
+func WithMaxBodySize(size int) Option {
+	return optionFunc(func(c *config.Config) { c.MaxBodySize = size })
+}
+
+func WithReadable(readable bool) Option {
+	return optionFunc(func(c *config.Config) { c.Readable = readable })
+}
+
+func WithEncoding(encoding config.Encoding) Option {
+	return optionFunc(func(c *config.Config) { c.Encoding = encoding })
+}
+
+func WithProfile(profile config.Profile) Option {
+	return optionFunc(func(c *config.Config) { c.Profile = profile })
+}
+
+func WithFingerprintNormalizer(normalize func(string) string) Option {
+	return optionFunc(func(c *config.Config) { c.FingerprintNormalizer = normalize })
+}
+
+// WithClock sets the clock supplying the time of the entries, e.g. config.FixedClock in tests.
+func WithClock(clock config.Clock) Option {
+	return optionFunc(func(c *config.Config) { c.Clock = clock })
+}
+
+// WithTimeZone writes the timestamps in an IANA time zone, e.g. "UTC" or "Asia/Bangkok".
+func WithTimeZone(name string) Option {
+	return optionFunc(func(c *config.Config) { c.TimeZone = name })
+}
+
+func WithTimeFormat(format config.TimeFormat) Option {
+	return optionFunc(func(c *config.Config) { c.TimeFormat = format })
+}
+
+// WithTimePrecision truncates the timestamps, e.g. to time.Millisecond.
+func WithTimePrecision(precision time.Duration) Option {
+	return optionFunc(func(c *config.Config) { c.TimePrecision = precision })
+}
+
+// WithLogID adds a unique, time-sortable "log_id" to every entry.
+func WithLogID(format config.LogIDFormat) Option {
+	return optionFunc(func(c *config.Config) { c.LogID = format })
+}
+
+// WithLogIDGenerator replaces the generator of the "log_id" and enables log IDs.
+func WithLogIDGenerator(generate logid.Generator) Option {
+	return optionFunc(func(c *config.Config) { c.LogIDGenerator = generate })
+}
+
+// WithCallerSkip reports the caller of every entry skip more frames up the stack, e.g. behind a logging wrapper.
+func WithCallerSkip(skip int) Option {
+	return optionFunc(func(c *config.Config) { c.CallerSkip = skip })
+}
+
+// WithCallerFunction adds the function name of the caller as "function".
+func WithCallerFunction(enabled bool) Option {
+	return optionFunc(func(c *config.Config) { c.CallerFunction = enabled })
+}
+
+// WithFullCallerPath writes the full path of the caller file instead of package/file.go.
+func WithFullCallerPath(enabled bool) Option {
+	return optionFunc(func(c *config.Config) { c.FullCallerPath = enabled })
+}
+
+// WithEnrich adds the host, Kubernetes and build metadata of the process to every entry, see config.EnrichConfig.
+func WithEnrich(enrich config.EnrichConfig) Option {
+	return optionFunc(func(c *config.Config) { c.Enrich = enrich })
+}
+
+func WithSampling(sampling config.SamplingConfig) Option {
+	return optionFunc(func(c *config.Config) { c.Sampling = sampling })
+}
+
+func WithOTLP(otlp config.OTLPConfig) Option {
+	return optionFunc(func(c *config.Config) { c.OTLP = otlp })
+}
+
+func WithAlert(alert config.AlertConfig) Option {
+	return optionFunc(func(c *config.Config) { c.Alert = alert })
+}
@@ -0,0 +1,68 @@
+package slog
+
+import (
+	"github.com/Sellsuki/sellsuki-go-logger/v2/config"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewConfig(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		assert.Equal(t, config.Default(), newConfig())
+	})
+
+	t.Run("config replaces the defaults", func(t *testing.T) {
+		cfg := config.Config{AppName: "order-service", Sampling: config.SamplingConfig{Tick: time.Minute}}
+		assert.Equal(t, cfg, newConfig(cfg))
+	})
+
+	t.Run("config replaces the options before it", func(t *testing.T) {
+		cfg := config.Default()
+		cfg.AppName = "order-service"
+		cfg.LogLevel = level.Info
+		cfg.Readable = false
+
+		c := newConfig(NewDevelopmentConfig(), WithAppName("payment-service"), cfg, WithVersion("v1.2.0"))
+
+		want := cfg
+		want.Version = "v1.2.0"
+		assert.Equal(t, want, c)
+	})
+
+	t.Run("options", func(t *testing.T) {
+		c := newConfig(WithAppName("order-service"), WithVersion("v1.2.0"), WithLevel(level.Warn), WithMaxBodySize(0))
+
+		want := config.Default()
+		want.AppName = "order-service"
+		want.Version = "v1.2.0"
+		want.LogLevel = level.Warn
+		want.MaxBodySize = 0
+		assert.Equal(t, want, c)
+	})
+
+	t.Run("options apply in order", func(t *testing.T) {
+		c := newConfig(NewDevelopmentConfig(), WithAppName("order-service"), WithLevel(level.Info), WithReadable(false))
+
+		want := NewDevelopmentConfig()
+		want.AppName = "order-service"
+		want.LogLevel = level.Info
+		want.Readable = false
+		assert.Equal(t, want, c)
+	})
+}
+
+func TestPresets(t *testing.T) {
+	prod := NewProductionConfig()
+	assert.Equal(t, level.Info, prod.LogLevel)
+	assert.Equal(t, config.EncodingJSON, prod.Encoding)
+	assert.False(t, prod.Sampling.Disabled)
+	assert.NoError(t, prod.Validate())
+
+	dev := NewDevelopmentConfig()
+	assert.Equal(t, level.Debug, dev.LogLevel)
+	assert.Equal(t, config.EncodingConsole, dev.Encoding)
+	assert.True(t, dev.Sampling.Disabled)
+	assert.NoError(t, dev.Validate())
+}
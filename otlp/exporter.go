@@ -0,0 +1,273 @@
+package otlp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go.uber.org/zap/zapcore"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const scopeName = "github.com/Sellsuki/sellsuki-go-logger"
+
+// Options configures an Exporter, zero values fall back to the defaults documented on each field.
+type Options struct {
+	Endpoint       string            // Endpoint is the OTLP/HTTP logs URL, e.g. http://otel-collector:4318/v1/logs.
+	Headers        map[string]string // Headers are added to every export request, e.g. for authentication.
+	ServiceName    string            // ServiceName is exported as the service.name resource attribute.
+	ServiceVersion string            // ServiceVersion is exported as the service.version resource attribute.
+	BatchSize      int               // BatchSize is the number of records that triggers an export, 100 by default.
+	MaxQueueSize   int               // MaxQueueSize is the max number of records waiting for export, the oldest are dropped over it, 10000 by default.
+	FlushInterval  time.Duration     // FlushInterval is the max time a record waits before export, 5s by default.
+	MaxRetries     int               // MaxRetries is the number of retries of a failed export, 3 by default, negative disables retries.
+	RetryBackoff   time.Duration     // RetryBackoff is the delay before the first retry, doubled on each retry, 500ms by default.
+	Client         *http.Client      // Client sends the export requests, a client with a 10s timeout by default.
+	OnDrop         func(n int)       // OnDrop is called with the number of records dropped over MaxQueueSize or after a failed export.
+}
+
+// Exporter batches log entries and sends them to an OpenTelemetry collector over OTLP/HTTP JSON.
+// Use Core to plug it into a zap logger, Sync flushes pending records and Close stops the exporter.
+type Exporter struct {
+	opts     Options
+	resource resource
+
+	mu      sync.Mutex
+	pending []logRecord
+
+	flushCh   chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// NewExporter creates an exporter and starts its background flush loop.
+func NewExporter(opts Options) *Exporter {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 100
+	}
+	if opts.MaxQueueSize <= 0 {
+		opts.MaxQueueSize = 10000
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = 5 * time.Second
+	}
+	if opts.MaxRetries < 0 {
+		opts.MaxRetries = 0
+	} else if opts.MaxRetries == 0 {
+		opts.MaxRetries = 3
+	}
+	if opts.RetryBackoff <= 0 {
+		opts.RetryBackoff = 500 * time.Millisecond
+	}
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	e := &Exporter{
+		opts: opts,
+		resource: resource{Attributes: []keyValue{
+			{Key: "service.name", Value: stringValue(opts.ServiceName)},
+			{Key: "service.version", Value: stringValue(opts.ServiceVersion)},
+		}},
+		flushCh: make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+
+	e.wg.Add(1)
+	go e.loop()
+
+	return e
+}
+
+// Core returns a zapcore.Core exporting every entry enabled by enab.
+func (e *Exporter) Core(enab zapcore.LevelEnabler) zapcore.Core {
+	return &core{LevelEnabler: enab, exporter: e}
+}
+
+// Sync exports all pending records and waits for the result.
+func (e *Exporter) Sync() error {
+	return e.flush()
+}
+
+// Close stops the flush loop and exports the remaining records.
+func (e *Exporter) Close() error {
+	e.closeOnce.Do(func() {
+		close(e.done)
+	})
+	e.wg.Wait()
+
+	return e.flush()
+}
+
+// add queues a record, it drops the oldest pending record when MaxQueueSize is reached, e.g. while the collector is down.
+func (e *Exporter) add(rec logRecord) {
+	e.mu.Lock()
+	dropped := len(e.pending) >= e.opts.MaxQueueSize
+	if dropped {
+		// Reslicing does not copy the queue, append moves it to a new array once the capacity left runs out.
+		e.pending[0] = logRecord{}
+		e.pending = e.pending[1:]
+	}
+	e.pending = append(e.pending, rec)
+	full := len(e.pending) >= e.opts.BatchSize
+	e.mu.Unlock()
+
+	if dropped {
+		e.drop(1)
+	}
+
+	if full {
+		select {
+		case e.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (e *Exporter) loop() {
+	defer e.wg.Done()
+
+	ticker := time.NewTicker(e.opts.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-e.flushCh:
+		case <-e.done:
+			return
+		}
+		_ = e.flush()
+	}
+}
+
+func (e *Exporter) flush() error {
+	e.mu.Lock()
+	batch := e.pending
+	e.pending = nil
+	e.mu.Unlock()
+
+	for len(batch) > 0 {
+		n := len(batch)
+		if n > e.opts.BatchSize {
+			n = e.opts.BatchSize
+		}
+
+		if err := e.export(batch[:n]); err != nil {
+			e.drop(len(batch))
+			return fmt.Errorf("otlp: dropped %d log records: %w", len(batch), err)
+		}
+
+		batch = batch[n:]
+	}
+
+	return nil
+}
+
+func (e *Exporter) drop(n int) {
+	if e.opts.OnDrop != nil {
+		e.opts.OnDrop(n)
+	}
+}
+
+func (e *Exporter) export(records []logRecord) error {
+	body, err := json.Marshal(exportRequest{ResourceLogs: []resourceLogs{{
+		Resource:  e.resource,
+		ScopeLogs: []scopeLogs{{Scope: scope{Name: scopeName}, LogRecords: records}},
+	}}})
+	if err != nil {
+		return err
+	}
+
+	backoff := e.opts.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		retryable, err := e.send(body)
+		if err == nil || !retryable || attempt >= e.opts.MaxRetries {
+			return err
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// send posts one export request, it reports whether a failure is worth retrying.
+func (e *Exporter) send(body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, e.opts.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.opts.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.opts.Client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode == http.StatusBadGateway,
+		resp.StatusCode == http.StatusServiceUnavailable, resp.StatusCode == http.StatusGatewayTimeout:
+		return true, errors.New(resp.Status)
+	default:
+		return false, errors.New(resp.Status)
+	}
+}
+
+// core adapts the exporter to zapcore.Core, fields added with With are kept per core.
+type core struct {
+	zapcore.LevelEnabler
+	exporter *Exporter
+	fields   []zapcore.Field
+}
+
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	return &core{
+		LevelEnabler: c.LevelEnabler,
+		exporter:     c.exporter,
+		fields:       append(c.fields[:len(c.fields):len(c.fields)], fields...),
+	}
+}
+
+func (c *core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	all := fields
+	if len(c.fields) > 0 {
+		all = append(c.fields[:len(c.fields):len(c.fields)], fields...)
+	}
+
+	rec, err := newRecord(ent, all)
+	if err != nil {
+		return err
+	}
+
+	c.exporter.add(rec)
+
+	// Like the zap io core, export panic and fatal entries before the process panics or exits.
+	if ent.Level > zapcore.ErrorLevel {
+		_ = c.exporter.Sync()
+	}
+
+	return nil
+}
+
+func (c *core) Sync() error {
+	return c.exporter.Sync()
+}
@@ -0,0 +1,233 @@
+package otlp
+
+import (
+	"encoding/json"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// collector is a local stand-in for the OTLP/HTTP logs endpoint.
+type collector struct {
+	mu       sync.Mutex
+	failures int
+	requests []map[string]any
+	headers  []http.Header
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.failures > 0 {
+		c.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	req := map[string]any{}
+	_ = json.Unmarshal(body, &req)
+	c.requests = append(c.requests, req)
+	c.headers = append(c.headers, r.Header.Clone())
+}
+
+func records(req map[string]any) []any {
+	rl := req["resourceLogs"].([]any)[0].(map[string]any)
+	return rl["scopeLogs"].([]any)[0].(map[string]any)["logRecords"].([]any)
+}
+
+func TestExporter(t *testing.T) {
+	c := &collector{failures: 1}
+	srv := httptest.NewServer(c)
+	defer srv.Close()
+
+	exp := NewExporter(Options{
+		Endpoint:       srv.URL + "/v1/logs",
+		Headers:        map[string]string{"Authorization": "Bearer token"},
+		ServiceName:    "order-service",
+		ServiceVersion: "v1.2.3",
+		BatchSize:      10,
+		FlushInterval:  time.Hour,
+		RetryBackoff:   time.Millisecond,
+	})
+	defer exp.Close()
+
+	logger := zap.New(exp.Core(zapcore.InfoLevel)).With(zap.String("app_name", "order-service"), zap.String("version", "v1.2.3"))
+	logger.Debug("skipped")
+	logger.Warn("Payment declined",
+		zap.String("log_id", "01HESGV6AKX5QZ8R9T3W2Y4N6M"),
+		zap.Int("alert", 1),
+		zap.String("log_type", "handler.http"),
+		zap.Any("data", map[string]any{
+			"http_request": map[string]any{"method": "POST", "path": "/payments"},
+			"tracing":      map[string]string{"trace_id": "0102030405060708090a0b0c0d0e0f10", "span_id": "0102030405060708"},
+			"duration":     0.5,
+			"tags":         []string{"a", "b"},
+		}),
+	)
+
+	assert.NoError(t, exp.Sync())
+
+	assert.Len(t, c.requests, 1)
+	assert.Equal(t, "Bearer token", c.headers[0].Get("Authorization"))
+	assert.Equal(t, "application/json", c.headers[0].Get("Content-Type"))
+
+	rl := c.requests[0]["resourceLogs"].([]any)[0].(map[string]any)
+	assert.Equal(t, []any{
+		map[string]any{"key": "service.name", "value": map[string]any{"stringValue": "order-service"}},
+		map[string]any{"key": "service.version", "value": map[string]any{"stringValue": "v1.2.3"}},
+	}, rl["resource"].(map[string]any)["attributes"])
+
+	recs := records(c.requests[0])
+	assert.Len(t, recs, 1)
+
+	rec := recs[0].(map[string]any)
+	assert.Equal(t, float64(13), rec["severityNumber"])
+	assert.Equal(t, "WARN", rec["severityText"])
+	assert.Equal(t, map[string]any{"stringValue": "Payment declined"}, rec["body"])
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", rec["traceId"])
+	assert.Equal(t, "0102030405060708", rec["spanId"])
+	assert.Equal(t, []any{
+		map[string]any{"key": "alert", "value": map[string]any{"intValue": "1"}},
+		map[string]any{"key": "data.duration", "value": map[string]any{"doubleValue": 0.5}},
+		map[string]any{"key": "data.http_request.method", "value": map[string]any{"stringValue": "POST"}},
+		map[string]any{"key": "data.http_request.path", "value": map[string]any{"stringValue": "/payments"}},
+		map[string]any{"key": "data.tags", "value": map[string]any{"arrayValue": map[string]any{"values": []any{
+			map[string]any{"stringValue": "a"}, map[string]any{"stringValue": "b"},
+		}}}},
+		map[string]any{"key": "log_id", "value": map[string]any{"stringValue": "01HESGV6AKX5QZ8R9T3W2Y4N6M"}},
+		map[string]any{"key": "log_type", "value": map[string]any{"stringValue": "handler.http"}},
+	}, rec["attributes"])
+}
+
+func TestExporter_BatchSize(t *testing.T) {
+	c := &collector{}
+	srv := httptest.NewServer(c)
+	defer srv.Close()
+
+	exp := NewExporter(Options{Endpoint: srv.URL, BatchSize: 2, FlushInterval: time.Hour})
+	logger := zap.New(exp.Core(zapcore.DebugLevel))
+
+	for i := 0; i < 5; i++ {
+		logger.Info("message")
+	}
+
+	assert.NoError(t, exp.Close())
+
+	total := 0
+	for _, req := range c.requests {
+		assert.LessOrEqual(t, len(records(req)), 2)
+		total += len(records(req))
+	}
+	assert.Equal(t, 5, total)
+}
+
+func TestExporter_MaxQueueSize(t *testing.T) {
+	c := &collector{}
+	srv := httptest.NewServer(c)
+	defer srv.Close()
+
+	dropped := 0
+	exp := NewExporter(Options{Endpoint: srv.URL, MaxQueueSize: 2, FlushInterval: time.Hour, OnDrop: func(n int) { dropped += n }})
+	logger := zap.New(exp.Core(zapcore.DebugLevel))
+
+	for i := 0; i < 100; i++ {
+		logger.Info("first")
+	}
+	logger.Info("second")
+	logger.Info("third")
+
+	assert.NoError(t, exp.Close())
+	assert.Equal(t, 100, dropped)
+
+	var bodies []any
+	for _, rec := range records(c.requests[0]) {
+		bodies = append(bodies, rec.(map[string]any)["body"].(map[string]any)["stringValue"])
+	}
+	assert.Equal(t, []any{"second", "third"}, bodies)
+}
+
+func TestExporter_FlushesPanic(t *testing.T) {
+	c := &collector{}
+	srv := httptest.NewServer(c)
+	defer srv.Close()
+
+	exp := NewExporter(Options{Endpoint: srv.URL, FlushInterval: time.Hour})
+	defer exp.Close()
+
+	// The record is exported before the logger panics, without a call to Sync.
+	assert.Panics(t, func() { zap.New(exp.Core(zapcore.DebugLevel)).Panic("Out of memory") })
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	assert.Len(t, c.requests, 1)
+}
+
+func TestExporter_Level(t *testing.T) {
+	c := &collector{}
+	srv := httptest.NewServer(c)
+	defer srv.Close()
+
+	exp := NewExporter(Options{Endpoint: srv.URL, FlushInterval: time.Hour})
+	logger := zap.New(exp.Core(zapcore.DebugLevel))
+
+	// Notice and Trace are written as zap info and debug, their level is carried by level.Field.
+	logger.Info("notice", level.Field(level.Notice))
+	logger.Debug("trace", level.Field(level.Trace))
+	logger.Info("info")
+
+	assert.NoError(t, exp.Close())
+
+	var got []any
+	for _, rec := range records(c.requests[0]) {
+		r := rec.(map[string]any)
+		got = append(got, r["severityText"], r["severityNumber"])
+	}
+	assert.Equal(t, []any{"NOTICE", float64(10), "TRACE", float64(1), "INFO", float64(9)}, got)
+}
+
+func TestExporter_GiveUp(t *testing.T) {
+	c := &collector{failures: 10}
+	srv := httptest.NewServer(c)
+	defer srv.Close()
+
+	dropped := 0
+	exp := NewExporter(Options{Endpoint: srv.URL, MaxRetries: 2, RetryBackoff: time.Millisecond, FlushInterval: time.Hour, OnDrop: func(n int) { dropped += n }})
+	defer exp.Close()
+
+	logger := zap.New(exp.Core(zapcore.DebugLevel))
+	logger.Info("message")
+	logger.Info("message")
+
+	assert.EqualError(t, exp.Sync(), "otlp: dropped 2 log records: 503 Service Unavailable")
+	assert.Equal(t, 7, c.failures)
+	assert.Equal(t, 2, dropped)
+}
+
+func TestSeverityNumber(t *testing.T) {
+	tests := []struct {
+		level level.Level
+		want  int
+	}{
+		{level.Trace, 1},
+		{level.Debug, 5},
+		{level.Info, 9},
+		{level.Notice, 10},
+		{level.Warn, 13},
+		{level.Error, 17},
+		{level.Panic, 21},
+		{level.Fatal, 24},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, SeverityNumber(tt.level))
+	}
+}
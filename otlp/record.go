@@ -0,0 +1,205 @@
+package otlp
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"go.uber.org/zap/zapcore"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The types below follow the OTLP/HTTP JSON encoding of ExportLogsServiceRequest.
+
+type exportRequest struct {
+	ResourceLogs []resourceLogs `json:"resourceLogs"`
+}
+
+type resourceLogs struct {
+	Resource  resource    `json:"resource"`
+	ScopeLogs []scopeLogs `json:"scopeLogs"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scopeLogs struct {
+	Scope      scope       `json:"scope"`
+	LogRecords []logRecord `json:"logRecords"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+type logRecord struct {
+	TimeUnixNano         string     `json:"timeUnixNano"`
+	ObservedTimeUnixNano string     `json:"observedTimeUnixNano"`
+	SeverityNumber       int        `json:"severityNumber"`
+	SeverityText         string     `json:"severityText"`
+	Body                 anyValue   `json:"body"`
+	Attributes           []keyValue `json:"attributes,omitempty"`
+	TraceID              string     `json:"traceId,omitempty"`
+	SpanID               string     `json:"spanId,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue *string     `json:"stringValue,omitempty"`
+	BoolValue   *bool       `json:"boolValue,omitempty"`
+	IntValue    *string     `json:"intValue,omitempty"`
+	DoubleValue *float64    `json:"doubleValue,omitempty"`
+	ArrayValue  *arrayValue `json:"arrayValue,omitempty"`
+}
+
+type arrayValue struct {
+	Values []anyValue `json:"values"`
+}
+
+// resourceKeys are envelope fields moved to the resource instead of the record attributes.
+var resourceKeys = map[string]struct{}{
+	"app_name": {},
+	"version":  {},
+}
+
+// SeverityNumber maps a level to the OpenTelemetry severity number.
+func SeverityNumber(l level.Level) int {
+	switch l {
+	case level.Trace:
+		return 1
+	case level.Debug:
+		return 5
+	case level.Info:
+		return 9
+	case level.Notice:
+		return 10
+	case level.Warn:
+		return 13
+	case level.Error:
+		return 17
+	case level.Panic:
+		return 21
+	case level.Fatal:
+		return 24
+	default:
+		return 9
+	}
+}
+
+// newRecord converts a zap entry and its fields into an OTLP log record.
+// Fields are normalized through JSON so payload structs become attributes with their JSON names.
+func newRecord(ent zapcore.Entry, fields []zapcore.Field) (logRecord, error) {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	b, err := json.Marshal(enc.Fields)
+	if err != nil {
+		return logRecord{}, err
+	}
+
+	values := map[string]any{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&values); err != nil {
+		return logRecord{}, err
+	}
+
+	lvl := level.FromEntry(ent, fields)
+	ts := strconv.FormatInt(ent.Time.UnixNano(), 10)
+	rec := logRecord{
+		TimeUnixNano:         ts,
+		ObservedTimeUnixNano: ts,
+		SeverityNumber:       SeverityNumber(lvl),
+		SeverityText:         strings.ToUpper(lvl.String()),
+		Body:                 stringValue(ent.Message),
+	}
+
+	if data, ok := values["data"].(map[string]any); ok {
+		if tracing, ok := data["tracing"].(map[string]any); ok {
+			rec.TraceID, _ = tracing["trace_id"].(string)
+			rec.SpanID, _ = tracing["span_id"].(string)
+			delete(data, "tracing")
+		}
+	}
+
+	for k := range resourceKeys {
+		delete(values, k)
+	}
+
+	if ent.Caller.Defined {
+		values["caller"] = ent.Caller.TrimmedPath()
+	}
+
+	rec.Attributes = attributes("", values)
+
+	return rec, nil
+}
+
+// attributes flattens nested objects into dotted keys sorted by key.
+func attributes(prefix string, m map[string]any) []keyValue {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var out []keyValue
+	for _, k := range keys {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+
+		if nested, ok := m[k].(map[string]any); ok {
+			out = append(out, attributes(key, nested)...)
+			continue
+		}
+
+		if v, ok := toAnyValue(m[k]); ok {
+			out = append(out, keyValue{Key: key, Value: v})
+		}
+	}
+
+	return out
+}
+
+func toAnyValue(v any) (anyValue, bool) {
+	switch t := v.(type) {
+	case string:
+		return stringValue(t), true
+	case bool:
+		return anyValue{BoolValue: &t}, true
+	case json.Number:
+		if _, err := t.Int64(); err == nil {
+			s := t.String()
+			return anyValue{IntValue: &s}, true
+		}
+		f, _ := t.Float64()
+		return anyValue{DoubleValue: &f}, true
+	case []any:
+		arr := &arrayValue{Values: []anyValue{}}
+		for _, item := range t {
+			if iv, ok := toAnyValue(item); ok {
+				arr.Values = append(arr.Values, iv)
+			}
+		}
+		return anyValue{ArrayValue: arr}, true
+	case map[string]any:
+		b, _ := json.Marshal(t)
+		return stringValue(string(b)), true
+	default:
+		return anyValue{}, false
+	}
+}
+
+func stringValue(s string) anyValue {
+	return anyValue{StringValue: &s}
+}
@@ -0,0 +1,54 @@
+package pretty
+
+import (
+	"bytes"
+	"encoding/json"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+var bufferPool = buffer.NewPool()
+
+// encoder renders every entry with Format, fields are collected by an embedded JSON encoder first.
+type encoder struct {
+	zapcore.Encoder
+	opts Options
+}
+
+// NewEncoder returns a zapcore.Encoder writing entries in the human-readable Format.
+// Time, duration and caller encoders are taken from cfg, envelope keys are fixed so Format can find them.
+func NewEncoder(cfg zapcore.EncoderConfig, opts Options) zapcore.Encoder {
+	cfg.TimeKey = "timestamp"
+	cfg.LevelKey = "level"
+	cfg.MessageKey = "message"
+	cfg.CallerKey = "caller"
+	cfg.StacktraceKey = "stacktrace"
+	cfg.EncodeLevel = zapcore.LowercaseLevelEncoder
+	cfg.LineEnding = "\n"
+
+	return &encoder{Encoder: zapcore.NewJSONEncoder(cfg), opts: opts}
+}
+
+func (e *encoder) Clone() zapcore.Encoder {
+	return &encoder{Encoder: e.Encoder.Clone(), opts: e.opts}
+}
+
+func (e *encoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	line, err := e.Encoder.EncodeEntry(ent, fields)
+	if err != nil {
+		return nil, err
+	}
+	defer line.Free()
+
+	entry := map[string]any{}
+	dec := json.NewDecoder(bytes.NewReader(line.Bytes()))
+	dec.UseNumber()
+	if err := dec.Decode(&entry); err != nil {
+		return nil, err
+	}
+
+	out := bufferPool.Get()
+	out.AppendString(Format(entry, e.opts))
+
+	return out, nil
+}
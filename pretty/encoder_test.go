@@ -0,0 +1,34 @@
+package pretty
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"testing"
+	"time"
+)
+
+func TestEncoder(t *testing.T) {
+	var buf bytes.Buffer
+
+	cfg := zap.NewProductionEncoderConfig()
+	cfg.EncodeTime = func(_ time.Time, enc zapcore.PrimitiveArrayEncoder) {
+		enc.AppendString("fixed")
+	}
+
+	core := zapcore.NewCore(NewEncoder(cfg, Options{}), zapcore.AddSync(&buf), zap.DebugLevel)
+	logger := zap.New(core).With(zap.String("app_name", "app"))
+
+	logger.Info("HandlerHTTP request received",
+		zap.String("log_type", "handler.http"),
+		zap.Any("data", map[string]any{
+			"http_request": map[string]any{"method": "POST", "path": "/orders"},
+			"app":          map[string]any{"user_id": 7},
+		}),
+	)
+
+	assert.Equal(t, "fixed INFO  [app] handler.http HandlerHTTP request received\n"+
+		"    → POST /orders\n"+
+		"    user_id = 7\n", buf.String())
+}
@@ -0,0 +1,324 @@
+package pretty
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	colorReset   = "\x1b[0m"
+	colorRed     = "\x1b[31m"
+	colorYellow  = "\x1b[33m"
+	colorBlue    = "\x1b[34m"
+	colorMagenta = "\x1b[35m"
+	colorCyan    = "\x1b[36m"
+	colorGray    = "\x1b[90m"
+)
+
+const indent = "    "
+
+// Options controls how Format renders an entry.
+type Options struct {
+	Color bool // Color enables ANSI colors, see IsTerminal.
+}
+
+// IsTerminal reports whether f is a character device, colors should be disabled otherwise.
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Format renders a decoded Sellsuki JSON log entry as human-readable text ending with a newline.
+// The first line holds the envelope, followed by a compact summary of known payloads,
+// the remaining data as aligned key=value pairs and the stack traces, each indented.
+func Format(entry map[string]any, opts Options) string {
+	p := printer{opts: opts}
+
+	data, _ := entry["data"].(map[string]any)
+	appName := str(entry["app_name"])
+	logType := str(entry["log_type"])
+
+	p.header(entry, logType)
+
+	rest := map[string]any{}
+	for k, v := range data {
+		rest[k] = v
+	}
+
+	for _, line := range summarize(rest) {
+		p.b.WriteString(indent + line + "\n")
+	}
+
+	var pairs [][2]string
+	if info, ok := entry["alert_info"].(map[string]any); ok && str(info["runbook_url"]) != "" {
+		pairs = append(pairs, [2]string{"runbook", str(info["runbook_url"])})
+	}
+
+	if errMsg, ok := rest["error"]; ok {
+		pairs = append(pairs, [2]string{"error", p.color(colorRed, str(errMsg))})
+		delete(rest, "error")
+	}
+
+	if tracing, ok := rest["tracing"].(map[string]any); ok {
+		pairs = append(pairs, [2]string{"trace_id", str(tracing["trace_id"])}, [2]string{"span_id", str(tracing["span_id"])})
+		delete(rest, "tracing")
+	}
+
+	stackTrace := str(rest["stack_trace"])
+	delete(rest, "stack_trace")
+
+	if app, ok := rest[appName].(map[string]any); ok && appName != "" {
+		pairs = append(pairs, flatten("", app)...)
+		delete(rest, appName)
+	}
+	pairs = append(pairs, flatten("", rest)...)
+
+	width := 0
+	for _, kv := range pairs {
+		if len(kv[0]) > width {
+			width = len(kv[0])
+		}
+	}
+	for _, kv := range pairs {
+		p.b.WriteString(indent + p.color(colorCyan, fmt.Sprintf("%-*s", width, kv[0])) + " = " + kv[1] + "\n")
+	}
+
+	for _, trace := range []string{stackTrace, str(entry["stacktrace"])} {
+		if trace == "" {
+			continue
+		}
+		for _, line := range strings.Split(strings.TrimRight(trace, "\n"), "\n") {
+			p.b.WriteString(indent + p.color(colorGray, strings.TrimLeft(line, "\t")) + "\n")
+		}
+	}
+
+	return p.b.String()
+}
+
+type printer struct {
+	opts Options
+	b    strings.Builder
+}
+
+func (p *printer) color(c string, s string) string {
+	if !p.opts.Color || s == "" {
+		return s
+	}
+	return c + s + colorReset
+}
+
+func (p *printer) header(entry map[string]any, logType string) {
+	lvl := strings.ToUpper(str(entry["level"]))
+
+	parts := []string{
+		str(entry["timestamp"]),
+		p.color(levelColor(lvl), fmt.Sprintf("%-5s", lvl)),
+	}
+
+	if app := str(entry["app_name"]); app != "" {
+		parts = append(parts, "["+app+"]")
+	}
+
+	if logType != "" {
+		parts = append(parts, p.color(colorMagenta, logType))
+	}
+
+	parts = append(parts, str(entry["message"]))
+
+	if alert := str(entry["alert"]); alert != "" && alert != "0" {
+		label := "ALERT"
+		if info, ok := entry["alert_info"].(map[string]any); ok {
+			label = join(label, strings.ToUpper(str(info["severity"])))
+			if team := str(info["team"]); team != "" {
+				label += " @" + team
+			}
+		}
+		parts = append(parts, p.color(colorRed, label))
+	}
+
+	if caller := str(entry["caller"]); caller != "" {
+		parts = append(parts, p.color(colorGray, caller))
+	}
+
+	p.b.WriteString(strings.Join(parts, " ") + "\n")
+}
+
+func levelColor(lvl string) string {
+	switch lvl {
+	case "DEBUG":
+		return colorMagenta
+	case "INFO":
+		return colorBlue
+	case "WARN":
+		return colorYellow
+	default:
+		return colorRed
+	}
+}
+
+// summarize renders the payloads of known log types as one-liners and removes them from data.
+func summarize(data map[string]any) []string {
+	var lines []string
+
+	take := func(key string) (map[string]any, bool) {
+		m, ok := data[key].(map[string]any)
+		if ok {
+			delete(data, key)
+		}
+		return m, ok
+	}
+
+	if req, ok := take("http_request"); ok {
+		lines = append(lines, join("→", str(req["method"]), str(req["path"]),
+			kv("handler", req["handler"]), kv("ip", req["remote_ip"]), kv("request_id", req["request_id"]),
+			kv("body", req["body"])))
+	}
+
+	if res, ok := take("http_response"); ok {
+		lines = append(lines, join("←", str(res["status"]), seconds(res["duration"]),
+			kv("request_id", res["request_id"]), kv("body", res["body"])))
+	}
+
+	if c, ok := take("http_client"); ok {
+		lines = append(lines, join("→", str(c["method"]), str(c["host"])+str(c["path"]), str(c["status"]),
+			seconds(c["duration"]), kv("retries", nonZero(c["retries"]))))
+	}
+
+	if msg, ok := take("kafka_message"); ok {
+		lines = append(lines, join("→", fmt.Sprintf("%s[%s]@%s", str(msg["topic"]), str(msg["partition"]), str(msg["offset"])),
+			kv("key", msg["key"]), kv("payload", msg["payload"])))
+	}
+
+	if res, ok := take("kafka_result"); ok {
+		lines = append(lines, join("←", seconds(res["duration"]), kv("committed", res["committed"])))
+	}
+
+	if ev, ok := take("event"); ok {
+		lines = append(lines, join(str(ev["entity"])+"#"+str(ev["reference_id"]), str(ev["action"]), "→", str(ev["result"]),
+			kv("data", ev["data"])))
+	}
+
+	if a, ok := take("audit"); ok {
+		refs := ""
+		if r, ok := a["entity_refs"].([]any); ok {
+			s := make([]string, len(r))
+			for i, v := range r {
+				s[i] = str(v)
+			}
+			refs = "[" + strings.Join(s, ",") + "]"
+		}
+		lines = append(lines, join(str(a["actor_type"])+":"+str(a["actor_id"]), str(a["action"]), str(a["entity"])+refs,
+			kv("owner", str(a["entity_owner_type"])+":"+str(a["entity_owner_id"]))))
+	}
+
+	if q, ok := take("db_query"); ok {
+		lines = append(lines, join(str(q["operation"]), seconds(q["duration"]), kv("rows", q["rows_affected"]),
+			kv("tx", q["tx_id"]), kv("slow", q["slow"]), quote(str(q["query"]))))
+	}
+
+	if j, ok := take("job"); ok {
+		lines = append(lines, join(str(j["name"]), str(j["stage"]), str(j["outcome"]), kv("run", j["run_id"]),
+			kv("attempt", j["attempt"]), kv("items", j["items_processed"]), seconds(j["duration"])))
+	}
+
+	return lines
+}
+
+// flatten turns nested maps into dotted keys sorted by key.
+func flatten(prefix string, m map[string]any) [][2]string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var out [][2]string
+	for _, k := range keys {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if nested, ok := m[k].(map[string]any); ok && len(nested) > 0 {
+			out = append(out, flatten(key, nested)...)
+			continue
+		}
+		out = append(out, [2]string{key, value(m[k])})
+	}
+	return out
+}
+
+func join(parts ...string) string {
+	var out []string
+	for _, p := range parts {
+		if p != "" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+func kv(key string, v any) string {
+	s := value(v)
+	if s == "" || s == `""` || s == "null" {
+		return ""
+	}
+	return key + "=" + s
+}
+
+func nonZero(v any) any {
+	if str(v) == "0" {
+		return nil
+	}
+	return v
+}
+
+func seconds(v any) string {
+	if v == nil {
+		return ""
+	}
+	return str(v) + "s"
+}
+
+// value formats a JSON value for key=value output, quoting strings that contain spaces or quotes.
+func value(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return quote(t)
+	case map[string]any, []any:
+		b, _ := json.Marshal(t)
+		return string(b)
+	default:
+		return str(t)
+	}
+}
+
+func quote(s string) string {
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+func str(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case json.Number:
+		return t.String()
+	default:
+		return fmt.Sprint(t)
+	}
+}
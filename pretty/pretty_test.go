@@ -0,0 +1,75 @@
+package pretty
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func decode(t *testing.T, line string) map[string]any {
+	entry := map[string]any{}
+	dec := json.NewDecoder(strings.NewReader(line))
+	dec.UseNumber()
+	if err := dec.Decode(&entry); err != nil {
+		t.Fatal(err)
+	}
+	return entry
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		opts Options
+		want string
+	}{
+		{
+			name: "Application log with app data, error and tracing",
+			line: `{"level":"error","timestamp":"2023-11-09T14:48:14.803+0700","caller":"main.go:10","message":"failed","app_name":"app","version":"v1","alert":1,"log_type":"application","data":{"error":"boom","tracing":{"trace_id":"t1","span_id":"s1"},"app":{"order_id":42,"note":"two words","nested":{"a":true}},"extra":[1,2]}}`,
+			want: "2023-11-09T14:48:14.803+0700 ERROR [app] application failed ALERT main.go:10\n" +
+				"    error    = boom\n" +
+				"    trace_id = t1\n" +
+				"    span_id  = s1\n" +
+				"    nested.a = true\n" +
+				"    note     = \"two words\"\n" +
+				"    order_id = 42\n" +
+				"    extra    = [1,2]\n",
+		},
+		{
+			name: "Alert with severity, team and runbook",
+			line: `{"level":"error","timestamp":"ts","message":"failed","app_name":"app","alert":1,"alert_info":{"severity":"p1","team":"payments","runbook_url":"https://runbook/payments"},"log_type":"application","data":{"error":"boom"}}`,
+			want: "ts ERROR [app] application failed ALERT P1 @payments\n" +
+				"    runbook = https://runbook/payments\n" +
+				"    error   = boom\n",
+		},
+		{
+			name: "HTTP request and response",
+			line: `{"level":"info","timestamp":"ts","message":"done","app_name":"app","alert":0,"log_type":"handler.http","data":{"http_request":{"method":"GET","path":"/orders/{id}","remote_ip":"10.0.0.1","handler":"","request_id":"r1","body":""},"http_response":{"status":404,"duration":0.012,"request_id":"r1","body":""}}}`,
+			want: "ts INFO  [app] handler.http done\n" +
+				"    → GET /orders/{id} ip=10.0.0.1 request_id=r1\n" +
+				"    ← 404 0.012s request_id=r1\n",
+		},
+		{
+			name: "Kafka message",
+			line: `{"level":"warn","timestamp":"ts","message":"retry","app_name":"app","alert":0,"log_type":"handler.kafka","data":{"kafka_message":{"topic":"orders","partition":3,"offset":120,"key":"k","payload":"{}"},"kafka_result":{"duration":1.5}}}`,
+			want: "ts WARN  [app] handler.kafka retry\n" +
+				"    → orders[3]@120 key=k payload={}\n" +
+				"    ← 1.5s\n",
+		},
+		{
+			name: "Stack trace on indented lines with colors",
+			line: `{"level":"debug","timestamp":"ts","message":"m","log_type":"application","data":{"stack_trace":"a.go:1 main.a\nb.go:2 main.b\n"}}`,
+			opts: Options{Color: true},
+			want: "ts \x1b[35mDEBUG\x1b[0m \x1b[35mapplication\x1b[0m m\n" +
+				"    \x1b[90ma.go:1 main.a\x1b[0m\n" +
+				"    \x1b[90mb.go:2 main.b\x1b[0m\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Format(decode(t, tt.line), tt.opts))
+		})
+	}
+}
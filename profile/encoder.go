@@ -0,0 +1,80 @@
+package profile
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/config"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+var bufferPool = buffer.NewPool()
+
+// encoder remaps the output of an embedded JSON encoder with a profile.
+type encoder struct {
+	zapcore.Encoder
+	profile    config.Profile
+	opts       Options
+	lineEnding string
+}
+
+// NewEncoder returns a zapcore.Encoder writing JSON entries reshaped for the given profile,
+// keys and value encoders are taken from cfg. Keys of the output are sorted.
+func NewEncoder(cfg zapcore.EncoderConfig, p config.Profile, opts Options) (zapcore.Encoder, error) {
+	if _, err := Remap(p, map[string]any{}, opts); err != nil {
+		return nil, err
+	}
+
+	lineEnding := cfg.LineEnding
+	if lineEnding == "" {
+		lineEnding = zapcore.DefaultLineEnding
+	}
+
+	return &encoder{Encoder: zapcore.NewJSONEncoder(cfg), profile: p, opts: opts, lineEnding: lineEnding}, nil
+}
+
+func (e *encoder) Clone() zapcore.Encoder {
+	return &encoder{Encoder: e.Encoder.Clone(), profile: e.profile, opts: e.opts, lineEnding: e.lineEnding}
+}
+
+func (e *encoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	line, err := e.Encoder.EncodeEntry(ent, fields)
+	if err != nil {
+		return nil, err
+	}
+	defer line.Free()
+
+	dec := json.NewDecoder(bytes.NewReader(line.Bytes()))
+	dec.UseNumber()
+
+	entry := map[string]any{}
+	if err := dec.Decode(&entry); err != nil {
+		return nil, err
+	}
+
+	// Trace and Notice are encoded with their zap level, put their own name back so Remap maps them.
+	if _, ok := entry["level"]; ok {
+		if lvl := level.FromEntry(ent, fields); lvl != level.FromZap(ent.Level) {
+			entry["level"] = lvl.String()
+		}
+	}
+
+	if entry, err = Remap(e.profile, entry, e.opts); err != nil {
+		return nil, err
+	}
+
+	out := bufferPool.Get()
+	enc := json.NewEncoder(out)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(entry); err != nil {
+		out.Free()
+		return nil, err
+	}
+
+	// json.Encoder always ends with "\n", swap it for the configured line ending.
+	out.TrimNewline()
+	out.AppendString(e.lineEnding)
+
+	return out, nil
+}
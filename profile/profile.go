@@ -0,0 +1,306 @@
+package profile
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/config"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/otlp"
+	"go.uber.org/zap/zapcore"
+	"strconv"
+	"strings"
+)
+
+// Options holds backend specific settings used by some profiles.
+type Options struct {
+	GCPProjectID string // GCPProjectID prefixes logging.googleapis.com/trace as projects/<id>/traces/<trace_id>.
+}
+
+// Profiles lists every profile accepted by Remap besides config.ProfileDefault.
+var Profiles = []config.Profile{config.ProfileGCP, config.ProfileDatadog, config.ProfileECS, config.ProfileOTel}
+
+// mapping moves the value at the dotted path From of a decoded entry to the top level key To.
+// Convert, when set, transforms the value before it is written.
+type mapping struct {
+	From    string
+	To      string
+	Convert func(any) any
+}
+
+var gcpMappings = []mapping{
+	{From: "timestamp", To: "time"},
+	{From: "level", To: "severity", Convert: lookup(map[string]string{
+		"trace": "DEBUG", "debug": "DEBUG", "info": "INFO", "notice": "NOTICE", "warn": "WARNING", "error": "ERROR",
+		"dpanic": "CRITICAL", "panic": "ALERT", "fatal": "EMERGENCY",
+	})},
+	{From: "data.tracing.span_id", To: "logging.googleapis.com/spanId"},
+}
+
+var datadogMappings = []mapping{
+	{From: "level", To: "status", Convert: lookup(map[string]string{
+		"trace": "debug", "dpanic": "critical", "panic": "alert", "fatal": "emergency",
+	})},
+	{From: "app_name", To: "service"},
+	{From: "data.tracing.trace_id", To: "dd.trace_id", Convert: datadogID},
+	{From: "data.tracing.span_id", To: "dd.span_id", Convert: datadogID},
+	{From: "data.error", To: "error.message"},
+	{From: "data.stack_trace", To: "error.stack"},
+	{From: "stacktrace", To: "error.stack"},
+	{From: "data.fingerprint", To: "error.fingerprint"},
+	{From: "data.http_request.method", To: "http.method"},
+	{From: "data.http_request.path", To: "http.url_details.path"},
+	{From: "data.http_request.remote_ip", To: "network.client.ip"},
+	{From: "data.http_request.request_id", To: "http.request_id"},
+	{From: "data.http_response.status", To: "http.status_code"},
+	{From: "data.http_response.duration", To: "duration", Convert: nanoseconds},
+	{From: "data.http_client.method", To: "http.method"},
+	{From: "data.http_client.host", To: "http.url_details.host"},
+	{From: "data.http_client.path", To: "http.url_details.path"},
+	{From: "data.http_client.status", To: "http.status_code"},
+	{From: "data.http_client.duration", To: "duration", Convert: nanoseconds},
+}
+
+var ecsMappings = []mapping{
+	{From: "timestamp", To: "@timestamp"},
+	{From: "level", To: "log.level"},
+	{From: "app_name", To: "service.name"},
+	{From: "version", To: "service.version"},
+	{From: "data.tracing.trace_id", To: "trace.id"},
+	{From: "data.tracing.span_id", To: "span.id"},
+	{From: "data.error", To: "error.message"},
+	{From: "data.stack_trace", To: "error.stack_trace"},
+	{From: "stacktrace", To: "error.stack_trace"},
+	{From: "data.http_request.method", To: "http.request.method"},
+	{From: "data.http_request.path", To: "url.path"},
+	{From: "data.http_request.remote_ip", To: "client.ip"},
+	{From: "data.http_request.request_id", To: "http.request.id"},
+	{From: "data.http_response.status", To: "http.response.status_code"},
+	{From: "data.http_response.duration", To: "event.duration", Convert: nanoseconds},
+	{From: "data.http_client.method", To: "http.request.method"},
+	{From: "data.http_client.host", To: "url.domain"},
+	{From: "data.http_client.path", To: "url.path"},
+	{From: "data.http_client.status", To: "http.response.status_code"},
+	{From: "data.http_client.duration", To: "event.duration", Convert: nanoseconds},
+}
+
+var otelMappings = []mapping{
+	{From: "message", To: "body"},
+	{From: "app_name", To: "service.name"},
+	{From: "version", To: "service.version"},
+	{From: "data.tracing.trace_id", To: "trace_id"},
+	{From: "data.tracing.span_id", To: "span_id"},
+	{From: "data.error", To: "exception.message"},
+	{From: "data.stack_trace", To: "exception.stacktrace"},
+	{From: "stacktrace", To: "exception.stacktrace"},
+	{From: "data.http_request.method", To: "http.request.method"},
+	{From: "data.http_request.path", To: "url.path"},
+	{From: "data.http_request.remote_ip", To: "client.address"},
+	{From: "data.http_response.status", To: "http.response.status_code"},
+	{From: "data.http_client.method", To: "http.request.method"},
+	{From: "data.http_client.host", To: "server.address"},
+	{From: "data.http_client.path", To: "url.path"},
+	{From: "data.http_client.status", To: "http.response.status_code"},
+	{From: "data.kafka_message.topic", To: "messaging.destination.name"},
+	{From: "data.kafka_message.partition", To: "messaging.destination.partition.id", Convert: toString},
+	{From: "data.kafka_message.offset", To: "messaging.kafka.offset"},
+	{From: "data.kafka_message.key", To: "messaging.kafka.message.key"},
+	{From: "data.db_query.operation", To: "db.operation.name"},
+	{From: "data.db_query.query", To: "db.query.text"},
+}
+
+// Remap reshapes a decoded Sellsuki JSON log entry for the given profile and returns it.
+// The entry is modified in place, fields without a counterpart in the target schema stay where they are.
+func Remap(p config.Profile, entry map[string]any, opts Options) (map[string]any, error) {
+	switch p {
+	case config.ProfileDefault:
+	case config.ProfileGCP:
+		remapGCP(entry, opts)
+	case config.ProfileDatadog:
+		apply(entry, datadogMappings)
+	case config.ProfileECS:
+		apply(entry, ecsMappings)
+		moveCaller(entry, "log.origin.file.name", "log.origin.file.line")
+		entry["ecs.version"] = "8.11.0"
+	case config.ProfileOTel:
+		if lvl, ok := entry["level"].(string); ok {
+			if l, ok := parseLevel(lvl); ok {
+				entry["severity_number"] = otlp.SeverityNumber(l)
+			}
+			entry["severity_text"] = strings.ToUpper(lvl)
+			delete(entry, "level")
+		}
+		apply(entry, otelMappings)
+		moveCaller(entry, "code.filepath", "code.lineno")
+		if _, ok := entry["messaging.destination.name"]; ok {
+			entry["messaging.system"] = "kafka"
+		}
+	default:
+		return nil, fmt.Errorf("unknown profile %q", p)
+	}
+
+	return entry, nil
+}
+
+func remapGCP(entry map[string]any, opts Options) {
+	apply(entry, gcpMappings)
+
+	if traceID, ok := take(entry, "data.tracing.trace_id"); ok {
+		if opts.GCPProjectID != "" {
+			traceID = fmt.Sprintf("projects/%s/traces/%v", opts.GCPProjectID, traceID)
+		}
+		entry["logging.googleapis.com/trace"] = traceID
+	}
+
+	if caller, ok := take(entry, "caller"); ok {
+		file, line := splitCaller(caller)
+		entry["logging.googleapis.com/sourceLocation"] = map[string]any{"file": file, "line": line}
+	}
+
+	httpRequest := map[string]any{}
+	for _, m := range []mapping{
+		{From: "data.http_request.method", To: "requestMethod"},
+		{From: "data.http_request.path", To: "requestUrl"},
+		{From: "data.http_request.remote_ip", To: "remoteIp"},
+		{From: "data.http_response.status", To: "status"},
+		{From: "data.http_response.duration", To: "latency", Convert: func(v any) any { return toString(v).(string) + "s" }},
+	} {
+		move(entry, httpRequest, m)
+	}
+	if len(httpRequest) > 0 {
+		entry["httpRequest"] = httpRequest
+	}
+}
+
+func apply(entry map[string]any, mappings []mapping) {
+	for _, m := range mappings {
+		if _, exists := entry[m.To]; exists {
+			continue
+		}
+		move(entry, entry, m)
+	}
+}
+
+func move(entry map[string]any, dst map[string]any, m mapping) {
+	v, ok := take(entry, m.From)
+	if !ok {
+		return
+	}
+	if m.Convert != nil {
+		v = m.Convert(v)
+	}
+	dst[m.To] = v
+}
+
+// moveCaller splits the "file:line" caller into two top level keys.
+func moveCaller(entry map[string]any, fileKey, lineKey string) {
+	caller, ok := take(entry, "caller")
+	if !ok {
+		return
+	}
+
+	file, line := splitCaller(caller)
+	entry[fileKey] = file
+	if n, err := strconv.Atoi(line); err == nil {
+		entry[lineKey] = n
+	}
+}
+
+func splitCaller(caller any) (file string, line string) {
+	s := fmt.Sprint(caller)
+	if i := strings.LastIndexByte(s, ':'); i >= 0 {
+		return s[:i], s[i+1:]
+	}
+	return s, ""
+}
+
+// take removes the value at a dotted path and prunes the parent objects left empty.
+func take(entry map[string]any, path string) (any, bool) {
+	keys := strings.Split(path, ".")
+
+	parents := []map[string]any{entry}
+	for _, k := range keys[:len(keys)-1] {
+		next, ok := parents[len(parents)-1][k].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		parents = append(parents, next)
+	}
+
+	last := parents[len(parents)-1]
+	v, ok := last[keys[len(keys)-1]]
+	if !ok {
+		return nil, false
+	}
+	delete(last, keys[len(keys)-1])
+
+	for i := len(parents) - 1; i > 0 && len(parents[i]) == 0; i-- {
+		delete(parents[i-1], keys[i-1])
+	}
+
+	return v, true
+}
+
+// parseLevel accepts the names of level.Level and the zap level names such as "dpanic".
+func parseLevel(s string) (level.Level, bool) {
+	if l, err := level.Parse(s); err == nil {
+		return l, true
+	}
+	if zl, err := zapcore.ParseLevel(s); err == nil {
+		return level.FromZap(zl), true
+	}
+	return level.Info, false
+}
+
+func lookup(table map[string]string) func(any) any {
+	return func(v any) any {
+		if s, ok := table[fmt.Sprint(v)]; ok {
+			return s
+		}
+		return v
+	}
+}
+
+// datadogID converts a hex trace or span ID into the decimal form used by Datadog,
+// 128-bit trace IDs keep their lower 64 bits.
+func datadogID(v any) any {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+	if len(s) > 16 {
+		s = s[len(s)-16:]
+	}
+	n, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return v
+	}
+	return strconv.FormatUint(n, 10)
+}
+
+// nanoseconds converts a duration in seconds into integer nanoseconds.
+func nanoseconds(v any) any {
+	var f float64
+	switch t := v.(type) {
+	case json.Number:
+		var err error
+		if f, err = t.Float64(); err != nil {
+			return v
+		}
+	case float64:
+		f = t
+	default:
+		return v
+	}
+	return int64(f * 1e9)
+}
+
+func toString(v any) any {
+	switch t := v.(type) {
+	case json.Number:
+		return t.String()
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	default:
+		return fmt.Sprint(t)
+	}
+}
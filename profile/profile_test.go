@@ -0,0 +1,163 @@
+package profile
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/config"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"testing"
+)
+
+const httpLine = `{"timestamp":"2024-01-02T03:04:05.000Z","level":"warn","caller":"api/order.go:42","message":"Order created",
+"app_name":"order-service","version":"v1.2.3","alert":0,"log_type":"handler.http","data":{
+"http_request":{"method":"POST","path":"/orders","remote_ip":"10.0.0.1","request_id":"req-1","handler":"createOrder"},
+"http_response":{"status":201,"duration":0.25,"request_id":"req-1"},
+"tracing":{"trace_id":"0af7651916cd43dd8448eb211c80319c","span_id":"b7ad6b7169203331"},
+"error":"boom"}}`
+
+func decode(t *testing.T, s string) map[string]any {
+	dec := json.NewDecoder(bytes.NewReader([]byte(s)))
+	dec.UseNumber()
+
+	m := map[string]any{}
+	assert.NoError(t, dec.Decode(&m))
+	return m
+}
+
+func TestRemap(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile config.Profile
+		opts    Options
+		want    string
+	}{
+		{
+			name:    "Default",
+			profile: config.ProfileDefault,
+			want:    httpLine,
+		},
+		{
+			name:    "GCP",
+			profile: config.ProfileGCP,
+			opts:    Options{GCPProjectID: "my-project"},
+			want: `{"time":"2024-01-02T03:04:05.000Z","severity":"WARNING","message":"Order created",
+"app_name":"order-service","version":"v1.2.3","alert":0,"log_type":"handler.http",
+"logging.googleapis.com/trace":"projects/my-project/traces/0af7651916cd43dd8448eb211c80319c",
+"logging.googleapis.com/spanId":"b7ad6b7169203331",
+"logging.googleapis.com/sourceLocation":{"file":"api/order.go","line":"42"},
+"httpRequest":{"requestMethod":"POST","requestUrl":"/orders","remoteIp":"10.0.0.1","status":201,"latency":"0.25s"},
+"data":{"http_request":{"request_id":"req-1","handler":"createOrder"},"http_response":{"request_id":"req-1"},"error":"boom"}}`,
+		},
+		{
+			name:    "Datadog",
+			profile: config.ProfileDatadog,
+			want: `{"timestamp":"2024-01-02T03:04:05.000Z","status":"warn","caller":"api/order.go:42","message":"Order created",
+"service":"order-service","version":"v1.2.3","alert":0,"log_type":"handler.http",
+"dd.trace_id":"9532127138774266268","dd.span_id":"13235353014750950193","error.message":"boom",
+"http.method":"POST","http.url_details.path":"/orders","network.client.ip":"10.0.0.1","http.request_id":"req-1",
+"http.status_code":201,"duration":250000000,
+"data":{"http_request":{"handler":"createOrder"},"http_response":{"request_id":"req-1"}}}`,
+		},
+		{
+			name:    "ECS",
+			profile: config.ProfileECS,
+			want: `{"@timestamp":"2024-01-02T03:04:05.000Z","log.level":"warn","message":"Order created",
+"log.origin.file.name":"api/order.go","log.origin.file.line":42,"ecs.version":"8.11.0",
+"service.name":"order-service","service.version":"v1.2.3","alert":0,"log_type":"handler.http",
+"trace.id":"0af7651916cd43dd8448eb211c80319c","span.id":"b7ad6b7169203331","error.message":"boom",
+"http.request.method":"POST","url.path":"/orders","client.ip":"10.0.0.1","http.request.id":"req-1",
+"http.response.status_code":201,"event.duration":250000000,
+"data":{"http_request":{"handler":"createOrder"},"http_response":{"request_id":"req-1"}}}`,
+		},
+		{
+			name:    "OTel",
+			profile: config.ProfileOTel,
+			want: `{"timestamp":"2024-01-02T03:04:05.000Z","severity_text":"WARN","severity_number":13,"body":"Order created",
+"code.filepath":"api/order.go","code.lineno":42,
+"service.name":"order-service","service.version":"v1.2.3","alert":0,"log_type":"handler.http",
+"trace_id":"0af7651916cd43dd8448eb211c80319c","span_id":"b7ad6b7169203331","exception.message":"boom",
+"http.request.method":"POST","url.path":"/orders","client.address":"10.0.0.1","http.response.status_code":201,
+"data":{"http_request":{"request_id":"req-1","handler":"createOrder"},"http_response":{"duration":0.25,"request_id":"req-1"}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Remap(tt.profile, decode(t, httpLine), tt.opts)
+			assert.NoError(t, err)
+
+			gotJSON, _ := json.Marshal(got)
+			wantJSON, _ := json.Marshal(decode(t, tt.want))
+			assert.JSONEq(t, string(wantJSON), string(gotJSON))
+		})
+	}
+}
+
+func TestRemap_OTelKafka(t *testing.T) {
+	got, err := Remap(config.ProfileOTel, decode(t, `{"level":"info","data":{"kafka_message":{"topic":"orders","partition":3,"offset":10}}}`), Options{})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "kafka", got["messaging.system"])
+	assert.Equal(t, "orders", got["messaging.destination.name"])
+	assert.Equal(t, "3", got["messaging.destination.partition.id"])
+	assert.Equal(t, json.Number("10"), got["messaging.kafka.offset"])
+	assert.NotContains(t, got, "data")
+}
+
+func TestRemap_Unknown(t *testing.T) {
+	_, err := Remap("splunk", map[string]any{}, Options{})
+	assert.EqualError(t, err, `unknown profile "splunk"`)
+
+	_, err = NewEncoder(zapcore.EncoderConfig{}, "splunk", Options{})
+	assert.EqualError(t, err, `unknown profile "splunk"`)
+}
+
+func TestEncoder(t *testing.T) {
+	enc, err := NewEncoder(zapcore.EncoderConfig{MessageKey: "message", LevelKey: "level", EncodeLevel: zapcore.LowercaseLevelEncoder}, config.ProfileECS, Options{})
+	assert.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	logger := zap.New(zapcore.NewCore(enc, zapcore.AddSync(buf), zapcore.DebugLevel))
+	logger.Info("a <b>", zap.String("app_name", "order-service"), zap.Any("data", map[string]any{
+		"tracing": map[string]string{"trace_id": "t", "span_id": "s"},
+	}))
+
+	assert.Equal(t, `{"ecs.version":"8.11.0","log.level":"info","message":"a <b>","service.name":"order-service","span.id":"s","trace.id":"t"}`+"\n", buf.String())
+}
+
+func TestEncoder_Level(t *testing.T) {
+	cfg := zapcore.EncoderConfig{MessageKey: "message", LevelKey: "level", EncodeLevel: zapcore.LowercaseLevelEncoder}
+
+	tests := []struct {
+		profile config.Profile
+		key     string
+		want    []any
+	}{
+		{config.ProfileOTel, "severity_number", []any{json.Number("10"), json.Number("1"), json.Number("9")}},
+		{config.ProfileOTel, "severity_text", []any{"NOTICE", "TRACE", "INFO"}},
+		{config.ProfileGCP, "severity", []any{"NOTICE", "DEBUG", "INFO"}},
+		{config.ProfileDatadog, "status", []any{"notice", "debug", "info"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.profile)+" "+tt.key, func(t *testing.T) {
+			enc, err := NewEncoder(cfg, tt.profile, Options{})
+			assert.NoError(t, err)
+
+			buf := &bytes.Buffer{}
+			logger := zap.New(zapcore.NewCore(enc, zapcore.AddSync(buf), zapcore.DebugLevel))
+			// Notice and Trace are written as zap info and debug, their level is carried by level.Field.
+			logger.Info("notice", level.Field(level.Notice))
+			logger.Debug("trace", level.Field(level.Trace))
+			logger.Info("info")
+
+			var got []any
+			for _, line := range bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n")) {
+				got = append(got, decode(t, string(line))[tt.key])
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
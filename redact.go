@@ -0,0 +1,36 @@
+package slog
+
+import (
+	"encoding/json"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/zap_logger"
+)
+
+// redactJSON replaces the value of every object key listed in fields (case-insensitive, at any depth)
+// with a placeholder. Bodies that are not valid JSON, e.g. form-encoded or cut at a max size, cannot be
+// redacted and are replaced with the placeholder as a whole so no listed field can leak.
+func redactJSON(body []byte, fields []string) []byte {
+	if len(fields) == 0 || len(body) == 0 {
+		return body
+	}
+
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return []byte(zap_logger.RedactedValue)
+	}
+
+	b, err := json.Marshal(zap_logger.Redact(v, fields))
+	if err != nil {
+		return []byte(zap_logger.RedactedValue)
+	}
+
+	return b
+}
+
+// truncateBody cuts body to at most max bytes, 0 means unlimited.
+func truncateBody(body []byte, max int) string {
+	if max > 0 && len(body) > max {
+		return string(body[:max])
+	}
+
+	return string(body)
+}
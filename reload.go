@@ -0,0 +1,183 @@
+package slog
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/config"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/zap_logger"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Reload validates cfg and replaces the configuration of the logger, including its level, per type levels, sampling,
+// redaction and sinks. Entries being written finish with the previous configuration, entries created before the reload
+// and written after it go to the new outputs. The OTLP exporter and the alert dispatcher are kept when their settings do not change.
+// The changed fields are logged as "config_changes" in an info entry.
+func (s *SukiLogger) Reload(cfg config.Config) error {
+	return s.reload(cfg, true)
+}
+
+// reload replaces the configuration, and the configuration set in code when base is true.
+// The changes are logged once reloadMu is released, so a hook calling Reload does not deadlock.
+func (s *SukiLogger) reload(cfg config.Config, base bool) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	changes, err := s.swap(cfg, base)
+	if err != nil {
+		return err
+	}
+
+	if len(changes) > 0 {
+		s.newLogger(level.Info, zap_logger.TypeApplication, "logger config reloaded").
+			WithAppData("config_changes", changes).
+			Write()
+	}
+
+	return nil
+}
+
+// swap builds the logger of cfg, replaces the current one and returns the changed fields.
+func (s *SukiLogger) swap(cfg config.Config, base bool) ([]string, error) {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	s.mu.RLock()
+	old, prev := s.config, s.sinks
+	s.mu.RUnlock()
+
+	logger, next, err := build(cfg, prev)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reload logger: %w", err)
+	}
+
+	s.mu.Lock()
+	previous := s.zapInstance
+	s.config, s.zapInstance, s.sinks = cfg, logger, next
+	if base {
+		s.base = cfg
+	}
+	s.mu.Unlock()
+
+	// Entries are written under the read lock, so nothing is left writing to the previous logger at this point.
+	if previous != nil {
+		_ = previous.Sync()
+	}
+	prev.close(next)
+
+	return config.Diff(old, cfg), nil
+}
+
+// Reload replaces the configuration of the logger created by Init, see SukiLogger.Reload.
+func Reload(cfg config.Config) error {
+	return sukiLogger.Reload(cfg)
+}
+
+// WatchOptions configures WatchConfig.
+type WatchOptions struct {
+	EnvPrefix string        // EnvPrefix applies the environment variables over the file, see config.Load.
+	Interval  time.Duration // Interval between checks of the file content, 5s by default, negative disables polling.
+	Signals   []os.Signal   // Signals trigger a reload, SIGHUP by default.
+}
+
+// WatchConfig reloads the logger from a YAML or JSON config file when its content changes and then stays the same
+// for an interval, or when the process receives one of the signals, until stop is called. The file and the environment
+// variables are applied over the configuration given to Init or Reload, so the settings made in code and missing from the file are kept.
+// A file that fails to load keeps the current configuration and is reported in an error entry.
+func (s *SukiLogger) WatchConfig(path string, opts WatchOptions) (stop func()) {
+	if opts.Interval == 0 {
+		opts.Interval = 5 * time.Second
+	}
+	if len(opts.Signals) == 0 {
+		opts.Signals = []os.Signal{syscall.SIGHUP}
+	}
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, opts.Signals...)
+
+	var tick <-chan time.Time
+	var ticker *time.Ticker
+	if opts.Interval > 0 {
+		ticker = time.NewTicker(opts.Interval)
+		tick = ticker.C
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	sum := fileSum(path)
+	var pending []byte
+
+	go func() {
+		defer close(stopped)
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-sig:
+				sum = fileSum(path)
+				s.reloadFile(path, opts.EnvPrefix)
+			case <-tick:
+				// A changed file is reloaded once it is unchanged for an interval, so a file being written is not read half way.
+				next := fileSum(path)
+				if bytes.Equal(next, sum) || !bytes.Equal(next, pending) {
+					pending = next
+					continue
+				}
+				sum = next
+				s.reloadFile(path, opts.EnvPrefix)
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(sig)
+			if ticker != nil {
+				ticker.Stop()
+			}
+			close(done)
+			<-stopped
+		})
+	}
+}
+
+// WatchConfig reloads the logger created by Init from a config file, see SukiLogger.WatchConfig.
+func WatchConfig(path string, opts WatchOptions) (stop func()) {
+	return sukiLogger.WatchConfig(path, opts)
+}
+
+func (s *SukiLogger) reloadFile(path string, envPrefix string) {
+	s.mu.RLock()
+	base := s.base
+	s.mu.RUnlock()
+
+	cfg, err := config.LoadOver(base, path, envPrefix)
+	if err == nil {
+		err = s.reload(cfg, false)
+	}
+
+	if err != nil {
+		s.newLogger(level.Error, zap_logger.TypeApplication, "failed to reload logger config").
+			WithError(err).
+			Write()
+	}
+}
+
+// fileSum returns the hash of the file content, nil when it cannot be read.
+func fileSum(path string) []byte {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	sum := sha256.Sum256(b)
+	return sum[:]
+}
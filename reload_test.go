@@ -0,0 +1,194 @@
+package slog
+
+import (
+	"github.com/Sellsuki/sellsuki-go-logger/v2/config"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/zap_logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReload(t *testing.T) {
+	stdout := useStdoutFile(t)
+
+	s := &SukiLogger{config: config.Default()}
+
+	cfg := config.Default()
+	cfg.AppName = "order-service"
+	cfg.TypeLevels = map[string]level.Level{"db.query": level.Debug}
+	assert.NoError(t, s.Reload(cfg))
+
+	assert.Equal(t, cfg, s.currentConfig())
+	assert.True(t, s.zapInstance.Core().Enabled(zapcore.DebugLevel), "the core lets through the lowest type level")
+
+	lines := decodeLines(t, stdout())
+	if assert.Len(t, lines, 1) {
+		assert.Equal(t, "logger config reloaded", lines[0]["message"])
+		assert.Contains(t, lines[0]["data"].(map[string]any)["order-service"], "config_changes")
+	}
+
+	err := s.Reload(config.Config{LogLevel: level.Warn})
+	assert.EqualError(t, err, "invalid config: app_name: must not be empty")
+	assert.Equal(t, cfg, s.currentConfig())
+}
+
+func TestReload_KeepsSinks(t *testing.T) {
+	useStdoutFile(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	s := &SukiLogger{config: config.Default()}
+
+	cfg := config.Default()
+	cfg.OTLP.Endpoint = srv.URL
+	assert.NoError(t, s.Reload(cfg))
+	exporter := s.sinks.otlp
+	assert.NotNil(t, exporter)
+
+	cfg.LogLevel = level.Debug
+	assert.NoError(t, s.Reload(cfg))
+	assert.Same(t, exporter, s.sinks.otlp)
+
+	cfg.OTLP.BatchSize = 10
+	assert.NoError(t, s.Reload(cfg))
+	assert.NotSame(t, exporter, s.sinks.otlp)
+
+	cfg.OTLP.Endpoint = ""
+	assert.NoError(t, s.Reload(cfg))
+	assert.Nil(t, s.sinks.otlp)
+}
+
+func TestReload_FromHook(t *testing.T) {
+	useStdoutFile(t)
+
+	s := &SukiLogger{config: config.Default()}
+	assert.NoError(t, s.Reload(s.config))
+
+	// The hook runs while the config_changes entry of the first reload is written.
+	var hooked bool
+	var reloaded error
+	s.AddHook(func(e *zap_logger.Entry) bool {
+		if e.Message == "logger config reloaded" && !hooked {
+			hooked = true
+			cfg := s.currentConfig()
+			cfg.LogLevel = level.Warn
+			reloaded = s.Reload(cfg)
+		}
+		return true
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		cfg := s.currentConfig()
+		cfg.LogLevel = level.Debug
+		assert.NoError(t, s.Reload(cfg))
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Reload called from a hook deadlocked")
+	}
+	assert.True(t, hooked)
+	assert.NoError(t, reloaded)
+	assert.Equal(t, level.Warn, s.currentConfig().LogLevel)
+}
+
+func TestReload_InFlightEntries(t *testing.T) {
+	useBufferLogger(t, config.Config{AppName: "app"})
+	s := sukiLogger
+	entry := s.newLogger(level.Info, zap_logger.TypeApplication, "in flight")
+
+	// Swap the zap logger the way Reload does once the entry is created.
+	buf := useBufferLogger(t, config.Config{AppName: "app"})
+	s.mu.Lock()
+	s.zapInstance = sukiLogger.zapInstance
+	s.mu.Unlock()
+
+	entry.Write()
+
+	lines := decodeLines(t, buf)
+	if assert.Len(t, lines, 1) {
+		assert.Equal(t, "in flight", lines[0]["msg"])
+	}
+}
+
+func TestWatchConfig(t *testing.T) {
+	useStdoutFile(t)
+
+	path := filepath.Join(t.TempDir(), "slog.yaml")
+	write := func(content string) {
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	write("app_name: order-service\n")
+
+	normalize := func(s string) string { return s }
+	s := &SukiLogger{config: config.Default()}
+	s.config.FingerprintNormalizer = normalize
+	assert.NoError(t, s.Reload(s.config))
+
+	stop := s.WatchConfig(path, WatchOptions{Interval: 10 * time.Millisecond})
+	defer stop()
+
+	write("app_name: order-service\nlog_level: debug\ntype_levels:\n  db.query: warn\nredact: [password]\n")
+	assert.Eventually(t, func() bool { return s.currentConfig().LogLevel == level.Debug }, time.Second, 10*time.Millisecond)
+
+	cfg := s.currentConfig()
+	assert.Equal(t, "order-service", cfg.AppName)
+	assert.Equal(t, map[string]level.Level{"db.query": level.Warn}, cfg.TypeLevels)
+	assert.Equal(t, []string{"password"}, cfg.Redact)
+	assert.NotNil(t, cfg.FingerprintNormalizer, "FingerprintNormalizer is kept")
+
+	// An invalid file keeps the current configuration.
+	write("log_level: verbose\n")
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, level.Debug, s.currentConfig().LogLevel)
+
+	stop()
+	write("app_name: order-service\n")
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, level.Debug, s.currentConfig().LogLevel)
+}
+
+func TestWatchConfig_KeepsCodeConfig(t *testing.T) {
+	useStdoutFile(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "slog.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("max_body_size: 10\n"), 0o600))
+
+	cfg := config.Default()
+	cfg.AppName = "order-service"
+	cfg.Version = "v1.2.0"
+	cfg.OTLP.Endpoint = srv.URL
+	s := &SukiLogger{config: config.Default()}
+	assert.NoError(t, s.Reload(cfg))
+	exporter := s.sinks.otlp
+
+	stop := s.WatchConfig(path, WatchOptions{Interval: 10 * time.Millisecond})
+	defer stop()
+
+	assert.NoError(t, os.WriteFile(path, []byte("log_level: debug\n"), 0o600))
+	assert.Eventually(t, func() bool { return s.currentConfig().LogLevel == level.Debug }, time.Second, 10*time.Millisecond)
+
+	got := s.currentConfig()
+	assert.Equal(t, "order-service", got.AppName)
+	assert.Equal(t, "v1.2.0", got.Version)
+	assert.Equal(t, srv.URL, got.OTLP.Endpoint)
+	assert.Same(t, exporter, s.sinks.otlp, "the sinks set in code are kept")
+
+	// Settings removed from the file go back to the ones set in code.
+	assert.NoError(t, os.WriteFile(path, []byte("max_body_size: 10\n"), 0o600))
+	assert.Eventually(t, func() bool { return s.currentConfig().MaxBodySize == 10 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, level.Info, s.currentConfig().LogLevel)
+}
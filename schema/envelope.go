@@ -0,0 +1,115 @@
+package schema
+
+import (
+	"github.com/Sellsuki/sellsuki-go-logger/v2/log"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/zap_logger"
+)
+
+// payloads maps every built-in log type to the payloads written under data.
+var payloads = map[zap_logger.Type]map[string]any{
+	zap_logger.TypeApplication: {},
+	zap_logger.TypeAudit:       {"audit": log.AuditPayload{}},
+	zap_logger.TypeEvent:       {"event": log.EventPayload{}},
+	zap_logger.TypeHandlerKafka: {
+		"kafka_message": log.KafkaMessagePayload{},
+		"kafka_result":  log.KafkaResultPayload{},
+	},
+	zap_logger.TypeHandlerHTTP: {
+		"http_request":  log.HTTPRequestPayload{},
+		"http_response": log.HTTPResponsePayload{},
+	},
+	zap_logger.TypeClientHTTP: {"http_client": log.HTTPClientPayload{}},
+	zap_logger.TypeDBQuery:    {"db_query": log.DBQueryPayload{}},
+	zap_logger.TypeHandlerJob: {"job": log.JobPayload{}},
+}
+
+// requiredPayloads lists the payload keys always written by the builder of a built-in type.
+var requiredPayloads = map[zap_logger.Type][]string{
+	zap_logger.TypeAudit:      {"audit"},
+	zap_logger.TypeEvent:      {"event"},
+	zap_logger.TypeHandlerJob: {"job"},
+}
+
+// ForType returns the schema of a whole log line of the given built-in or registered type.
+func ForType(t zap_logger.Type) (*Schema, bool) {
+	keys, ok := payloads[t]
+	required := requiredPayloads[t]
+
+	if !ok {
+		def, registered := zap_logger.LookupType(t)
+		if !registered {
+			return nil, false
+		}
+		keys = map[string]any{def.PayloadKey: def.Payload}
+		required = []string{def.PayloadKey}
+	}
+
+	data := &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"error":        {Type: "string"},
+			"stack_trace":  {Type: "string"},
+			"schema_error": {Type: "string"},
+			"tracing": {
+				Type: "object",
+				Properties: map[string]*Schema{
+					"trace_id": {Type: "string"},
+					"span_id":  {Type: "string"},
+				},
+				Required: []string{"trace_id", "span_id"},
+			},
+		},
+		Required: required,
+	}
+	for k, v := range keys {
+		data.Properties[k] = Generate(v)
+	}
+
+	return &Schema{
+		Schema: draft,
+		Title:  string(t),
+		Type:   "object",
+		Properties: map[string]*Schema{
+			"log_id":      {Type: "string"},
+			"timestamp":   {Type: "string"},
+			"level":       {Type: "string", Enum: []any{"debug", "info", "warn", "error", "dpanic", "panic", "fatal"}},
+			"message":     {Type: "string"},
+			"caller":      {Type: "string"},
+			"function":    {Type: "string"},
+			"stacktrace":  {Type: "string"},
+			"app_name":    {Type: "string"},
+			"version":     {Type: "string"},
+			"environment": {Type: "string"},
+			"region":      {Type: "string"},
+			"host": {Type: "object", Properties: map[string]*Schema{
+				"name": {Type: "string"},
+				"pid":  {Type: "integer"},
+			}},
+			"k8s": {Type: "object", Properties: map[string]*Schema{
+				"pod":       {Type: "string"},
+				"namespace": {Type: "string"},
+				"node":      {Type: "string"},
+			}},
+			"build": {Type: "object", Properties: map[string]*Schema{
+				"revision":   {Type: "string"},
+				"go_version": {Type: "string"},
+			}},
+			"alert":      {Type: "integer", Enum: []any{0, 1}},
+			"alert_info": Generate(log.AlertInfo{}),
+			"log_type":   {Type: "string", Const: string(t)},
+			"data":       data,
+		},
+		Required: []string{"timestamp", "level", "message", "app_name", "version", "alert", "log_type", "data"},
+	}, true
+}
+
+// All returns the schema of every built-in and registered log type keyed by log type.
+func All() map[zap_logger.Type]*Schema {
+	all := map[zap_logger.Type]*Schema{}
+	for _, t := range zap_logger.Types() {
+		if s, ok := ForType(t); ok {
+			all[t] = s
+		}
+	}
+	return all
+}
@@ -0,0 +1,64 @@
+package schema
+
+import (
+	"github.com/Sellsuki/sellsuki-go-logger/v2/log"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/zap_logger"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGenerate(t *testing.T) {
+	type embedded struct {
+		ID string `json:"id"`
+	}
+	type payload struct {
+		embedded
+		Name     string            `json:"name"`
+		Count    int64             `json:"count,omitempty"`
+		Ratio    float64           `json:"ratio"`
+		Tags     []string          `json:"tags"`
+		Headers  map[string]string `json:"headers"`
+		At       time.Time         `json:"at"`
+		Next     *embedded         `json:"next"`
+		Ignored  string            `json:"-"`
+		Untagged bool
+		private  string
+	}
+
+	s := Generate(payload{})
+
+	assert.Equal(t, "object", s.Type)
+	assert.Equal(t, []string{"id", "name", "ratio", "tags", "headers", "at", "next", "Untagged"}, s.Required)
+	assert.Equal(t, &Schema{Type: "string"}, s.Properties["id"])
+	assert.Equal(t, &Schema{Type: "integer"}, s.Properties["count"])
+	assert.Equal(t, &Schema{Type: "number"}, s.Properties["ratio"])
+	assert.Equal(t, &Schema{Type: []string{"array", "null"}, Items: &Schema{Type: "string"}}, s.Properties["tags"])
+	assert.Equal(t, &Schema{Type: []string{"object", "null"}}, s.Properties["headers"])
+	assert.Equal(t, &Schema{Type: "string", Format: "date-time"}, s.Properties["at"])
+	assert.Equal(t, []string{"object", "null"}, s.Properties["next"].Type)
+	assert.Equal(t, &Schema{Type: "boolean"}, s.Properties["Untagged"])
+	assert.NotContains(t, s.Properties, "Ignored")
+	assert.NotContains(t, s.Properties, "private")
+}
+
+func TestForType(t *testing.T) {
+	s, ok := ForType(zap_logger.TypeHandlerHTTP)
+	assert.True(t, ok)
+	assert.Equal(t, "handler.http", s.Properties["log_type"].Const)
+	assert.Contains(t, s.Properties["data"].Properties, "http_request")
+	assert.Contains(t, s.Properties["data"].Properties, "http_response")
+	assert.Empty(t, s.Properties["data"].Required)
+
+	zap_logger.MustRegisterType(zap_logger.TypeDefinition{Name: "handler.schema_test", PayloadKey: "audit_like", Payload: log.AuditPayload{}})
+	t.Cleanup(func() { zap_logger.UnregisterType("handler.schema_test") })
+	s, ok = ForType("handler.schema_test")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"audit_like"}, s.Properties["data"].Required)
+	assert.Equal(t, Generate(log.AuditPayload{}), s.Properties["data"].Properties["audit_like"])
+
+	_, ok = ForType("handler.unknown")
+	assert.False(t, ok)
+
+	assert.Contains(t, All(), zap_logger.TypeApplication)
+}
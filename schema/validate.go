@@ -0,0 +1,283 @@
+package schema
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/zap_logger"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ValidationError describes a single schema violation at a JSON path such as "data.audit.action".
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// ValidationErrors is returned by Validate when a log line violates its schema.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Validator validates log lines against the schemas of Types and of the built-in and registered types.
+// Types holds whole log line schemas by log_type, e.g. of the custom types of a service decoded from the JSON
+// of All in a binary registering them, so they can be validated where the types are not registered.
+type Validator struct {
+	Types map[zap_logger.Type]*Schema
+}
+
+// Validate checks a single JSON log line against the schema of its log_type.
+func Validate(line []byte) error {
+	return Validator{}.Validate(line)
+}
+
+// ValidateStream validates every non-empty line of r and calls report for each invalid one.
+// It returns the number of lines checked and the number of invalid lines.
+func ValidateStream(r io.Reader, report func(lineNo int, err error)) (total int, invalid int, err error) {
+	return Validator{}.ValidateStream(r, report)
+}
+
+// Validate checks a single JSON log line against the schema of its log_type, see the package Validate.
+func (val Validator) Validate(line []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	m, ok := v.(map[string]any)
+	if !ok {
+		return ValidationErrors{{Message: "log line is not a JSON object"}}
+	}
+
+	logType, _ := m["log_type"].(string)
+	s, ok := val.Types[zap_logger.Type(logType)]
+	if !ok {
+		s, ok = ForType(zap_logger.Type(logType))
+	}
+	if !ok {
+		return ValidationErrors{{Path: "log_type", Message: fmt.Sprintf("unknown log type %q", logType)}}
+	}
+
+	if errs := s.Validate(v); len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// ValidateStream validates every non-empty line of r, see the package ValidateStream.
+func (val Validator) ValidateStream(r io.Reader, report func(lineNo int, err error)) (total int, invalid int, err error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		total++
+		if vErr := val.Validate(line); vErr != nil {
+			invalid++
+			report(lineNo, vErr)
+		}
+	}
+
+	return total, invalid, scanner.Err()
+}
+
+// Validate checks a value decoded with json.Decoder.UseNumber against the schema.
+func (s *Schema) Validate(v any) ValidationErrors {
+	var errs ValidationErrors
+	s.validate("", v, &errs)
+	return errs
+}
+
+func (s *Schema) validate(path string, v any, errs *ValidationErrors) {
+	fail := func(format string, args ...any) {
+		*errs = append(*errs, ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if types := s.types(); len(types) > 0 && !matchesAnyType(v, types) {
+		fail("expected %s, got %s", strings.Join(types, " or "), typeOf(v))
+		return
+	}
+
+	if s.Const != nil && !equal(s.Const, v) {
+		fail("expected %v, got %v", s.Const, v)
+	}
+
+	if len(s.Enum) > 0 {
+		found := false
+		for _, e := range s.Enum {
+			if equal(e, v) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fail("value %v is not one of %v", v, s.Enum)
+		}
+	}
+
+	switch t := v.(type) {
+	case map[string]any:
+		for _, r := range s.Required {
+			if _, ok := t[r]; !ok {
+				*errs = append(*errs, ValidationError{Path: join(path, r), Message: "required property is missing"})
+			}
+		}
+
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			if p, ok := s.Properties[k]; ok {
+				p.validate(join(path, k), t[k], errs)
+			} else if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				*errs = append(*errs, ValidationError{Path: join(path, k), Message: "additional property is not allowed"})
+			}
+		}
+	case []any:
+		if s.Items != nil {
+			for i, item := range t {
+				s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, errs)
+			}
+		}
+	}
+}
+
+func (s *Schema) types() []string {
+	switch t := s.Type.(type) {
+	case string:
+		return []string{t}
+	case []string:
+		return t
+	case []any:
+		// A list of types decoded from JSON, e.g. a schema read from a file.
+		types := make([]string, 0, len(t))
+		for _, v := range t {
+			if s, ok := v.(string); ok {
+				types = append(types, s)
+			}
+		}
+		return types
+	default:
+		return nil
+	}
+}
+
+func matchesAnyType(v any, types []string) bool {
+	for _, t := range types {
+		if matchesType(v, t) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesType(v any, t string) bool {
+	switch t {
+	case "null":
+		return v == nil
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	case "string":
+		_, ok := v.(string)
+		return ok
+	case "number":
+		_, ok := v.(json.Number)
+		return ok
+	case "integer":
+		n, ok := v.(json.Number)
+		if !ok {
+			return false
+		}
+		if _, err := n.Int64(); err == nil {
+			return true
+		}
+		f, err := n.Float64()
+		return err == nil && f == float64(int64(f))
+	case "object":
+		_, ok := v.(map[string]any)
+		return ok
+	case "array":
+		_, ok := v.([]any)
+		return ok
+	default:
+		return false
+	}
+}
+
+func typeOf(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+// equal compares a schema value with a decoded JSON value, numbers are compared by value.
+func equal(schemaValue, v any) bool {
+	if n, ok := v.(json.Number); ok {
+		f, err := n.Float64()
+		if err != nil {
+			return false
+		}
+		switch s := schemaValue.(type) {
+		case int:
+			return float64(s) == f
+		case float64:
+			return s == f
+		default:
+			return false
+		}
+	}
+
+	return reflect.DeepEqual(schemaValue, v)
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,94 @@
+package schema
+
+import (
+	"encoding/json"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/zap_logger"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		wantErr string
+	}{
+		{
+			name: "Valid application log",
+			line: `{"level":"info","timestamp":"2023-11-09T14:48:14.803+0700","caller":"examples/application_log_test.go:44","message":"Info message","app_name":"sampleApp","version":"v1.0.0","alert":0,"log_type":"application","data":{"error":"error message here","sampleApp":{"field2":"value2"}}}`,
+		},
+		{
+			name: "Valid audit log",
+			line: `{"level":"info","timestamp":"2023-11-09T14:48:14.803+0700","caller":"examples/audit_log_test.go:35","message":"Audit message","app_name":"harry_squatter","version":"the_boy_who_lifted","alert":0,"log_type":"audit","data":{"audit":{"actor_type":"hawkward.wizard","actor_id":"magic_user_42","action":"create","entity":"hawkward.spell.banned","entity_refs":["dead_rift","bicep_curse"],"entity_owner_type":"fantasy_realm.system","entity_owner_id":"realm_keeper_5678"},"error":"you got mail","harry_squatter":{"app_data":"app_data_value"}}}`,
+		},
+		{
+			name: "Valid http log with null headers",
+			line: `{"level":"info","timestamp":"2023-11-09T14:48:14.803+0700","caller":"examples/handler_http_log_test.go:47","message":"HandlerHTTP request processed successfully","app_name":"sampleApp","version":"v1.0.0","alert":0,"log_type":"handler.http","data":{"http_response":{"status":200,"duration":2,"body":"{\"result\": \"success\"}","request_id":"unique-request-id","headers":null}}}`,
+		},
+		{
+			name: "Valid kafka log",
+			line: `{"level":"info","timestamp":"2023-11-09T14:48:14.803+0700","caller":"examples/handler_kafka_log_test.go:49","message":"HandlerKafka message processed Failed","app_name":"sampleApp","version":"v1.0.0","alert":0,"log_type":"handler.kafka","data":{"error":"error message here","kafka_result":{"duration":3},"sampleApp":{"field2":"value2"}}}`,
+		},
+		{
+			name:    "Not JSON",
+			line:    `panic: runtime error`,
+			wantErr: "invalid JSON",
+		},
+		{
+			name:    "Unknown log type",
+			line:    `{"log_type":"handler.smtp"}`,
+			wantErr: `log_type: unknown log type "handler.smtp"`,
+		},
+		{
+			name:    "Missing envelope keys and wrong alert",
+			line:    `{"level":"info","timestamp":"t","message":"m","alert":2,"log_type":"application","data":{}}`,
+			wantErr: "app_name: required property is missing; version: required property is missing; alert: value 2 is not one of [0 1]",
+		},
+		{
+			name:    "Invalid payload",
+			line:    `{"level":"info","timestamp":"t","message":"m","app_name":"a","version":"v","alert":0,"log_type":"event","data":{"event":{"entity":"order","reference_id":1,"action":"create","result":"success","data":"","extra":true}}}`,
+			wantErr: "data.event.extra: additional property is not allowed; data.event.reference_id: expected string, got number",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate([]byte(tt.line))
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateStream(t *testing.T) {
+	input := strings.Join([]string{
+		`{"level":"info","timestamp":"t","message":"m","app_name":"a","version":"v","alert":0,"log_type":"application","data":{}}`,
+		``,
+		`not json`,
+		`{"level":"info","timestamp":"t","message":"m","app_name":"a","version":"v","alert":1,"log_type":"application","data":{}}`,
+	}, "\n")
+
+	var reported []int
+	total, invalid, err := ValidateStream(strings.NewReader(input), func(lineNo int, err error) {
+		reported = append(reported, lineNo)
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Equal(t, 1, invalid)
+	assert.Equal(t, []int{3}, reported)
+}
+
+func TestValidator_Types(t *testing.T) {
+	var s Schema
+	assert.NoError(t, json.Unmarshal([]byte(`{"type":"object","properties":{"log_type":{"const":"handler.grpc"},"data":{"type":["object","null"]}},"required":["data"]}`), &s))
+	v := Validator{Types: map[zap_logger.Type]*Schema{"handler.grpc": &s}}
+
+	assert.NoError(t, v.Validate([]byte(`{"log_type":"handler.grpc","data":null}`)))
+	assert.EqualError(t, v.Validate([]byte(`{"log_type":"handler.grpc","data":1}`)), "data: expected object or null, got number")
+	assert.EqualError(t, Validate([]byte(`{"log_type":"handler.grpc","data":null}`)), `log_type: unknown log type "handler.grpc"`)
+}
@@ -2,12 +2,18 @@ package slog
 
 import (
 	"fmt"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/alert"
 	"github.com/Sellsuki/sellsuki-go-logger/v2/config"
 	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
 	"github.com/Sellsuki/sellsuki-go-logger/v2/log"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/metrics"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/otlp"
 	"github.com/Sellsuki/sellsuki-go-logger/v2/zap_logger"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"net/url"
+	"os"
+	"reflect"
 	"sync"
 	"time"
 )
@@ -15,110 +21,373 @@ import (
 // SukiLogger is a basically a Singleton wrapper for slog/log/zap_logger
 
 type SukiLogger struct {
+	mu          sync.RWMutex // mu guards the fields replaced by Reload.
 	config      config.Config
+	base        config.Config // base is the configuration set in code, WatchConfig applies the file over it.
 	zapInstance *zap.Logger
+	sinks       sinks
+	hooks       zap_logger.Hooks
+	defaults    bool // defaults is set when InitDefault created the logger, the next Init applies its options.
+
+	reloadMu sync.Mutex
 }
 
 var sukiLoggerOnce sync.Once
 
 var sukiLogger *SukiLogger
 
-// Init initialize the logger
-// Do not run this function in parallel
-func Init(c ...config.Config) {
-	sukiLoggerOnce.Do(func() {
-		cfg := config.Config{
-			LogLevel:    level.Info,
-			AppName:     "unknown",
-			Version:     "v0.0.0",
-			MaxBodySize: 1048576,
-		}
-		if len(c) > 0 {
-			cfg = c[0]
-		}
+// metricsSink is the zap sink scheme of stdout and stderr counting the bytes written in metrics.Default.
+const metricsSink = "sellsuki-metrics"
 
-		zCfg := zap.Config{
-			Level:       zap.NewAtomicLevelAt(level.ToZap(cfg.LogLevel)),
-			Development: false,
-			Sampling: &zap.SamplingConfig{
-				Initial:    100,
-				Thereafter: 100,
-			},
-			Encoding: "json",
-			EncoderConfig: zapcore.EncoderConfig{
-				TimeKey:        "timestamp",
-				LevelKey:       "level",
-				NameKey:        "logger",
-				CallerKey:      "caller",
-				FunctionKey:    zapcore.OmitKey,
-				MessageKey:     "message",
-				StacktraceKey:  "stacktrace",
-				LineEnding:     zapcore.DefaultLineEnding,
-				EncodeLevel:    zapcore.LowercaseLevelEncoder,
-				EncodeTime:     zapcore.ISO8601TimeEncoder,
-				EncodeDuration: zapcore.SecondsDurationEncoder,
-				EncodeCaller:   zapcore.ShortCallerEncoder,
-			},
-			OutputPaths:      []string{"stdout"},
-			ErrorOutputPaths: []string{"stdout"},
+func init() {
+	_ = zap.RegisterSink(metricsSink, func(u *url.URL) (zap.Sink, error) {
+		f := os.Stdout
+		if u.Host == "stderr" {
+			f = os.Stderr
 		}
+		return sink{metrics.Default.WriteSyncer(f)}, nil
+	})
+}
 
-		if cfg.Readable {
-			zCfg.Encoding = "console"
-			zCfg.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
-		}
+type sink struct {
+	zapcore.WriteSyncer
+}
 
-		if cfg.HardCodedTime != "" {
-			zCfg.EncoderConfig.EncodeTime = func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
-				enc.AppendString(cfg.HardCodedTime)
-			}
-		}
+func (sink) Close() error {
+	return nil
+}
+
+// Init initialize the logger with the options applied in order over config.Default(),
+// e.g. Init(WithAppName("order-service"), WithLevel(level.Debug)) or Init(cfg), a config.Config replaces the options before it.
+// Only the first call creates the logger, unless it was created by InitDefault, later calls do nothing.
+// Do not run this function in parallel
+func Init(opts ...Option) {
+	created := initOnce(opts, false)
+	if created || !sukiLogger.defaults {
+		return
+	}
 
-		logger, err := zCfg.Build(zap.AddCallerSkip(1))
+	sukiLogger.defaults = false
+	if err := Reload(newConfig(opts...)); err != nil {
+		panic(fmt.Errorf("failed to init logger: %w", err))
+	}
+}
+
+// InitDefault creates the logger with the defaults unless it exists, a later Init still applies its options.
+// It is meant for packages writing on behalf of a service that may call Init after them, e.g. v1compat.
+func InitDefault() {
+	initOnce(nil, true)
+}
+
+// initOnce creates the logger on the first call, it reports whether it did.
+func initOnce(opts []Option, defaults bool) (created bool) {
+	sukiLoggerOnce.Do(func() {
+		cfg := newConfig(opts...)
+
+		logger, s, err := build(cfg, sinks{})
 		if err != nil {
 			panic(fmt.Errorf("failed to init logger: %w", err))
 		}
 
 		defer logger.Sync()
 
-		sukiLogger = &SukiLogger{zapInstance: logger, config: cfg}
+		sukiLogger = &SukiLogger{zapInstance: logger, config: cfg, base: cfg, sinks: s, defaults: defaults}
+		created = true
 	})
+	return created
+}
+
+// sinks are the outputs teed with stdout, they are kept by Reload when their settings do not change.
+type sinks struct {
+	otlp        *otlp.Exporter
+	otlpOptions otlp.Options
+	alert       *alert.Dispatcher
+	alertConfig config.AlertConfig
+}
+
+// close closes the sinks not reused by next.
+func (s sinks) close(next sinks) {
+	if s.otlp != nil && s.otlp != next.otlp {
+		_ = s.otlp.Close()
+	}
+	if s.alert != nil && s.alert != next.alert {
+		_ = s.alert.Close()
+	}
+}
+
+// build creates the zap logger of a configuration, reusing the sinks of prev with the same settings.
+func build(cfg config.Config, prev sinks) (*zap.Logger, sinks, error) {
+	var next sinks
+
+	zCfg := zap.Config{
+		// Per type levels are checked by zap_logger, the core lets through the lowest of them.
+		Level:       zap.NewAtomicLevelAt(level.ToZap(cfg.MinLevel())),
+		Development: false,
+		Encoding:    zapEncoding(cfg),
+		EncoderConfig: zapcore.EncoderConfig{
+			TimeKey:        "timestamp",
+			LevelKey:       "level",
+			NameKey:        "logger",
+			CallerKey:      "caller",
+			FunctionKey:    zapcore.OmitKey,
+			MessageKey:     "message",
+			StacktraceKey:  "stacktrace",
+			LineEnding:     zapcore.DefaultLineEnding,
+			EncodeLevel:    zapcore.LowercaseLevelEncoder,
+			EncodeTime:     zapcore.ISO8601TimeEncoder,
+			EncodeDuration: zapcore.SecondsDurationEncoder,
+			EncodeCaller:   zapcore.ShortCallerEncoder,
+		},
+		OutputPaths:      []string{metricsSink + "://stdout"},
+		ErrorOutputPaths: []string{"stdout"},
+	}
+
+	if cfg.CallerFunction {
+		zCfg.EncoderConfig.FunctionKey = "function"
+	}
+	if cfg.FullCallerPath {
+		zCfg.EncoderConfig.EncodeCaller = zapcore.FullCallerEncoder
+	}
+
+	encodeTime, err := timeEncoder(cfg)
+	if err != nil {
+		return nil, sinks{}, err
+	}
+	zCfg.EncoderConfig.EncodeTime = encodeTime
+
+	zapOpts := []zap.Option{
+		// The caller skip covers zap_logger.Logger.Write and output.Log.
+		zap.AddCallerSkip(2),
+		// Sampling is applied here rather than in zap.Config so metrics.Default only counts the entries written.
+		zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+			return sampled(metrics.Default.Core(c), cfg.Sampling)
+		}),
+	}
+	if cfg.Clock != nil {
+		zapOpts = append(zapOpts, zap.WithClock(clock{cfg.Clock}))
+	}
+
+	logger, err := zCfg.Build(zapOpts...)
+	if err != nil {
+		return nil, sinks{}, err
+	}
+
+	if cfg.OTLP.Endpoint != "" {
+		next.otlpOptions = otlp.Options{
+			Endpoint:       cfg.OTLP.Endpoint,
+			Headers:        cfg.OTLP.Headers,
+			ServiceName:    cfg.AppName,
+			ServiceVersion: cfg.Version,
+			BatchSize:      cfg.OTLP.BatchSize,
+			FlushInterval:  cfg.OTLP.FlushInterval,
+			MaxQueueSize:   cfg.OTLP.MaxQueueSize,
+			MaxRetries:     cfg.OTLP.MaxRetries,
+		}
+		if prev.otlp != nil && reflect.DeepEqual(prev.otlpOptions, next.otlpOptions) {
+			next.otlp = prev.otlp
+		} else {
+			// OnDrop is set on a copy, funcs never compare equal and the exporter would be replaced on every reload.
+			opts := next.otlpOptions
+			opts.OnDrop = func(n int) { metrics.Default.AddDropped("otlp", n) }
+			next.otlp = otlp.NewExporter(opts)
+		}
+
+		logger = logger.WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+			return zapcore.NewTee(c, next.otlp.Core(zCfg.Level))
+		}))
+	}
+
+	if len(cfg.Alert.Webhooks) > 0 {
+		next.alertConfig = cfg.Alert
+		if prev.alert != nil && reflect.DeepEqual(prev.alertConfig, next.alertConfig) {
+			next.alert = prev.alert
+		} else {
+			webhooks := make([]alert.Webhook, len(cfg.Alert.Webhooks))
+			for i, w := range cfg.Alert.Webhooks {
+				webhooks[i] = alert.Webhook{
+					Kind:       alert.Kind(w.Kind),
+					URL:        w.URL,
+					Token:      w.Token,
+					Headers:    w.Headers,
+					Template:   w.Template,
+					Teams:      w.Teams,
+					Severities: w.Severities,
+				}
+			}
+
+			dispatcher, err := alert.NewDispatcher(alert.Options{
+				Webhooks:     webhooks,
+				DedupWindow:  cfg.Alert.DedupWindow,
+				RateLimit:    cfg.Alert.RateLimit,
+				RateInterval: cfg.Alert.RateInterval,
+				OnDrop: func(alert.Alert) {
+					metrics.Default.AddDropped("alert", 1)
+				},
+			})
+			if err != nil {
+				// Close the exporter created above, it is not used by anything yet.
+				next.close(prev)
+				return nil, sinks{}, err
+			}
+			next.alert = dispatcher
+		}
+
+		logger = logger.WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+			return zapcore.NewTee(c, next.alert.Core(zCfg.Level))
+		}))
+	}
+
+	return logger, next, nil
+}
+
+// clock adapts a config.Clock to zapcore.Clock.
+type clock struct {
+	config.Clock
+}
+
+func (clock) NewTicker(d time.Duration) *time.Ticker {
+	return time.NewTicker(d)
+}
+
+// output writes through the zap logger of the current configuration,
+// so entries created before a reload and written after it are not lost.
+type output struct {
+	s    *SukiLogger
+	skip int
+}
+
+func (o output) Log(lvl zapcore.Level, msg string, fields ...zapcore.Field) {
+	o.s.mu.RLock()
+	defer o.s.mu.RUnlock()
+
+	if o.skip > 0 {
+		o.s.zapInstance.WithOptions(zap.AddCallerSkip(o.skip)).Log(lvl, msg, fields...)
+		return
+	}
+	o.s.zapInstance.Log(lvl, msg, fields...)
+}
+
+// WithCallerSkip reports the caller of an entry skip more frames up the stack.
+func (o output) WithCallerSkip(skip int) log.ZapLogger {
+	o.skip += skip
+	return o
+}
+
+func (o output) Enabled(lvl zapcore.Level) bool {
+	o.s.mu.RLock()
+	defer o.s.mu.RUnlock()
+
+	return o.s.zapInstance.Core().Enabled(lvl)
+}
+
+// sampled wraps c with a sampler counting the dropped entries in metrics.Default, unless sampling is disabled.
+func sampled(c zapcore.Core, s config.SamplingConfig) zapcore.Core {
+	if s.Disabled {
+		return c
+	}
+
+	if s.Initial == 0 {
+		s.Initial = 100
+	}
+	if s.Thereafter == 0 {
+		s.Thereafter = 100
+	}
+	if s.Tick == 0 {
+		s.Tick = time.Second
+	}
+
+	return zapcore.NewSamplerWithOptions(c, s.Tick, s.Initial, s.Thereafter, zapcore.SamplerHook(metrics.Default.SamplerHook))
+}
+
+// newLogger creates an entry builder writing through the hooks of the logger.
+func (s *SukiLogger) newLogger(l level.Level, t zap_logger.Type, msg string) *zap_logger.Logger {
+	logger := zap_logger.New(output{s: s}, s.currentConfig(), l, t, msg)
+	logger.Hooks = &s.hooks
+	return logger
+}
+
+func (s *SukiLogger) currentConfig() config.Config {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.config
+}
+
+// AddHook appends a hook called with every entry before it is written.
+// Hooks run in the order they were added and may modify the entry, drop it by returning false or fan it out with Entry.Also.
+func (s *SukiLogger) AddHook(hook zap_logger.Hook) {
+	s.hooks.Add(hook)
+}
+
+// AddHook appends a hook to the logger created by Init, see SukiLogger.AddHook.
+func AddHook(hook zap_logger.Hook) {
+	sukiLogger.AddHook(hook)
+}
+
+// Helper marks the function calling it as a logging helper, entries written from it report the first caller
+// that is not a helper, like testing.T.Helper. Call it at the start of the helper.
+func Helper() {
+	zap_logger.MarkHelper(1)
+}
+
+// Sync flushes buffered entries, including the ones waiting for the OTLP exporter or the alert webhooks.
+// Call it before the application exits.
+func Sync() error {
+	sukiLogger.mu.RLock()
+	defer sukiLogger.mu.RUnlock()
+
+	return sukiLogger.zapInstance.Sync()
+}
+
+// Trace creates an entry finer than Debug, it is written with the zap debug level.
+func Trace(msg string) log.Log {
+	return sukiLogger.newLogger(level.Trace, zap_logger.TypeApplication, msg)
 }
 
 func Debug(msg string) log.Log {
-	return zap_logger.New(sukiLogger.zapInstance, sukiLogger.config, level.Debug, zap_logger.TypeApplication, msg)
+	return sukiLogger.newLogger(level.Debug, zap_logger.TypeApplication, msg)
 }
 
 func Info(msg string) log.Log {
-	return zap_logger.New(sukiLogger.zapInstance, sukiLogger.config, level.Info, zap_logger.TypeApplication, msg)
+	return sukiLogger.newLogger(level.Info, zap_logger.TypeApplication, msg)
+}
+
+// Notice creates an entry for a significant but normal event, it is written with the zap info level.
+func Notice(msg string) log.Log {
+	return sukiLogger.newLogger(level.Notice, zap_logger.TypeApplication, msg)
 }
 
 func Warn(msg string) log.Log {
-	return zap_logger.New(sukiLogger.zapInstance, sukiLogger.config, level.Warn, zap_logger.TypeApplication, msg)
+	return sukiLogger.newLogger(level.Warn, zap_logger.TypeApplication, msg)
 }
 
 func Error(msg string) log.Log {
-	return zap_logger.New(sukiLogger.zapInstance, sukiLogger.config, level.Error, zap_logger.TypeApplication, msg)
+	return sukiLogger.newLogger(level.Error, zap_logger.TypeApplication, msg)
 }
 
 func Panic(msg string) log.Log {
-	return zap_logger.New(sukiLogger.zapInstance, sukiLogger.config, level.Panic, zap_logger.TypeApplication, msg)
+	return sukiLogger.newLogger(level.Panic, zap_logger.TypeApplication, msg)
 }
 
 func Fatal(msg string) log.Log {
-	return zap_logger.New(sukiLogger.zapInstance, sukiLogger.config, level.Fatal, zap_logger.TypeApplication, msg)
+	return sukiLogger.newLogger(level.Fatal, zap_logger.TypeApplication, msg)
 }
 
 func Event(msg string, payload log.EventPayload) log.Log {
-	return zap_logger.New(sukiLogger.zapInstance, sukiLogger.config, level.Info, zap_logger.TypeEvent, msg).
+	return sukiLogger.newLogger(level.Info, zap_logger.TypeEvent, msg).
 		WithField("event", payload)
 }
 
 func Audit(msg string, payload log.AuditPayload) log.Log {
-	return zap_logger.New(sukiLogger.zapInstance, sukiLogger.config, level.Info, zap_logger.TypeAudit, msg).
+	return sukiLogger.newLogger(level.Info, zap_logger.TypeAudit, msg).
 		WithField("audit", payload)
 }
 
+func Job(msg string, payload log.JobPayload) log.Log {
+	return sukiLogger.newLogger(level.Info, zap_logger.TypeHandlerJob, msg).
+		WithField("job", payload)
+}
+
 func Kafka(msg string, kMsg *log.KafkaMessagePayload, kRes *log.KafkaResultPayload) log.Log {
 	payload := map[string]any{}
 
@@ -130,7 +399,7 @@ func Kafka(msg string, kMsg *log.KafkaMessagePayload, kRes *log.KafkaResultPaylo
 		payload["kafka_result"] = kRes
 	}
 
-	return zap_logger.New(sukiLogger.zapInstance, sukiLogger.config, level.Info, zap_logger.TypeHandlerKafka, msg).
+	return sukiLogger.newLogger(level.Info, zap_logger.TypeHandlerKafka, msg).
 		WithFields(payload)
 }
 
@@ -145,7 +414,47 @@ func HTTP(msg string, req *log.HTTPRequestPayload, res *log.HTTPResponsePayload)
 		payload["http_response"] = res
 	}
 
-	return zap_logger.New(sukiLogger.zapInstance, sukiLogger.config, level.Info, zap_logger.TypeHandlerHTTP, msg).
+	return sukiLogger.newLogger(level.Info, zap_logger.TypeHandlerHTTP, msg).
 		WithFields(payload)
 
 }
+
+func HTTPClient(msg string, payload *log.HTTPClientPayload) log.Log {
+	l := sukiLogger.newLogger(level.Info, zap_logger.TypeClientHTTP, msg)
+
+	if payload == nil {
+		return l
+	}
+
+	return l.WithField("http_client", payload)
+}
+
+func DBQuery(msg string, payload *log.DBQueryPayload) log.Log {
+	l := sukiLogger.newLogger(level.Info, zap_logger.TypeDBQuery, msg)
+
+	if payload == nil {
+		return l
+	}
+
+	return l.WithField("db_query", payload)
+}
+
+// Custom creates an entry of a type registered with zap_logger.RegisterType.
+// The entry is still written when the type is unknown or the payload is invalid, with the reason in "schema_error".
+func Custom(t zap_logger.Type, msg string, payload any) log.Log {
+	def, ok := zap_logger.LookupType(t)
+	if !ok {
+		return sukiLogger.newLogger(level.Info, t, msg).
+			WithField("schema_error", fmt.Sprintf("log type %q is not registered", t))
+	}
+
+	l := sukiLogger.newLogger(def.DefaultLevel, t, msg)
+
+	if def.Validate != nil {
+		if err := def.Validate(payload); err != nil {
+			l.Data["schema_error"] = err.Error()
+		}
+	}
+
+	return l.WithField(def.PayloadKey, payload)
+}
@@ -0,0 +1,72 @@
+package slog
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/config"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// useBufferLogger replaces the singleton with a logger writing JSON into the returned buffer
+// and restores the previous one when the test ends.
+func useBufferLogger(t *testing.T, cfg config.Config) *bytes.Buffer {
+	var buf bytes.Buffer
+
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.EncodeTime = func(_ time.Time, enc zapcore.PrimitiveArrayEncoder) {
+		enc.AppendString("fixed")
+	}
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(encoderConfig), zapcore.AddSync(&buf), zap.NewAtomicLevelAt(zap.DebugLevel))
+
+	prev := sukiLogger
+	sukiLogger = &SukiLogger{zapInstance: zap.New(core), config: cfg}
+	t.Cleanup(func() { sukiLogger = prev })
+
+	return &buf
+}
+
+// useStdoutFile points os.Stdout at a file, so the loggers built by Init or Reload during the test write into it,
+// and restores it when the test ends. The returned function reads what was written so far.
+func useStdoutFile(t *testing.T) func() *bytes.Buffer {
+	f, err := os.Create(filepath.Join(t.TempDir(), "stdout"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prev := os.Stdout
+	os.Stdout = f
+	t.Cleanup(func() {
+		os.Stdout = prev
+		_ = f.Close()
+	})
+
+	return func() *bytes.Buffer {
+		b, err := os.ReadFile(f.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return bytes.NewBuffer(b)
+	}
+}
+
+// decodeLines decodes every JSON line written into buf.
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var out []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		m := map[string]any{}
+		if err := json.Unmarshal([]byte(line), &m); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		out = append(out, m)
+	}
+	return out
+}
@@ -0,0 +1,101 @@
+package slog
+
+import (
+	"errors"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/config"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/zap_logger"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+type grpcPayload struct {
+	Method string `json:"method"`
+	Code   int    `json:"code"`
+}
+
+func TestCustom(t *testing.T) {
+	buf := useBufferLogger(t, config.Config{AppName: "app"})
+
+	grpcType := zap_logger.MustRegisterType(zap_logger.TypeDefinition{
+		Name:         "handler.grpc_test",
+		PayloadKey:   "grpc",
+		DefaultLevel: level.Warn,
+		Validate: func(payload any) error {
+			if p, ok := payload.(grpcPayload); !ok || p.Method == "" {
+				return errors.New("method is required")
+			}
+			return nil
+		},
+	})
+	t.Cleanup(func() { zap_logger.UnregisterType(grpcType) })
+
+	Custom(grpcType, "GRPC request", grpcPayload{Method: "/orders.Order/Get", Code: 0}).
+		WithAppData("key", "value").
+		Write()
+	Custom(grpcType, "GRPC request", grpcPayload{}).Write()
+	Custom("handler.unregistered", "Unknown", grpcPayload{}).Write()
+
+	lines := decodeLines(t, buf)
+	assert.Len(t, lines, 3)
+
+	assert.Equal(t, "warn", lines[0]["level"])
+	assert.Equal(t, "handler.grpc_test", lines[0]["log_type"])
+	assert.Equal(t, map[string]any{
+		"grpc": map[string]any{"method": "/orders.Order/Get", "code": float64(0)},
+		"app":  map[string]any{"key": "value"},
+	}, lines[0]["data"])
+
+	assert.Equal(t, "method is required", lines[1]["data"].(map[string]any)["schema_error"])
+
+	assert.Equal(t, "info", lines[2]["level"])
+	assert.Equal(t, `log type "handler.unregistered" is not registered`, lines[2]["data"].(map[string]any)["schema_error"])
+}
+
+func TestAddHook(t *testing.T) {
+	buf := useBufferLogger(t, config.Config{AppName: "app"})
+
+	var forwarded []string
+	AddHook(func(e *zap_logger.Entry) bool {
+		e.AppFields["tenant"] = "t1"
+		return true
+	})
+	AddHook(func(e *zap_logger.Entry) bool {
+		return e.Message != "health check"
+	})
+	AddHook(func(e *zap_logger.Entry) bool {
+		if !e.Level.Less(level.Error) {
+			forwarded = append(forwarded, e.Message)
+		}
+		return true
+	})
+
+	Info("health check").Write()
+	Info("order created").Write()
+	Error("order failed").WithError(errors.New("boom")).Write()
+
+	lines := decodeLines(t, buf)
+	assert.Len(t, lines, 2)
+	assert.Equal(t, "order created", lines[0]["msg"])
+	assert.Equal(t, map[string]any{"tenant": "t1"}, lines[0]["data"].(map[string]any)["app"])
+	assert.Equal(t, "boom", lines[1]["data"].(map[string]any)["error"])
+	assert.Equal(t, []string{"order failed"}, forwarded)
+}
+
+func TestInitDefault(t *testing.T) {
+	prev := sukiLogger
+	sukiLogger, sukiLoggerOnce = nil, sync.Once{}
+	t.Cleanup(func() { sukiLogger, sukiLoggerOnce = prev, sync.Once{} })
+
+	InitDefault()
+	assert.Equal(t, "unknown", sukiLogger.currentConfig().AppName)
+
+	// Init after InitDefault applies its options once, later calls do nothing like before.
+	Init(WithAppName("order-service"))
+	assert.Equal(t, "order-service", sukiLogger.currentConfig().AppName)
+
+	Init(WithAppName("ignored"))
+	InitDefault()
+	assert.Equal(t, "order-service", sukiLogger.currentConfig().AppName)
+}
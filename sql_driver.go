@@ -0,0 +1,394 @@
+package slog
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/log"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/zap_logger"
+	"go.opentelemetry.io/otel/trace"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// SQLDriverOptions configures the db.query logs written by WrapSQLDriver and WrapSQLConnector.
+type SQLDriverOptions struct {
+	LogArgs           bool          // Log bound argument values, by default only the argument count is logged.
+	SlowThreshold     time.Duration // Statements taking at least this long are logged as Warn with alert, 0 disables.
+	SampleFastQueries int           // Log only every Nth successful statement below SlowThreshold, 0 or 1 logs all.
+
+	// RedactArg reports whether a logged argument is replaced with "[REDACTED]", e.g. the password of an insert into users.
+	// Named arguments, e.g. sql.Named("password", p), are also redacted when their name is listed in config.Redact.
+	RedactArg func(query string, arg driver.NamedValue) bool
+}
+
+// WrapSQLDriver wraps a database/sql driver so every statement executed through it writes a db.query log.
+//
+//	sql.Register("postgres+slog", slog.WrapSQLDriver(&pq.Driver{}, slog.SQLDriverOptions{SlowThreshold: time.Second}))
+//	db, err := sql.Open("postgres+slog", dsn)
+func WrapSQLDriver(d driver.Driver, opts SQLDriverOptions) driver.Driver {
+	return &sqlDriver{Driver: d, logger: &sqlLogger{opts: opts}}
+}
+
+// WrapSQLConnector wraps a driver.Connector for use with sql.OpenDB, see WrapSQLDriver.
+func WrapSQLConnector(c driver.Connector, opts SQLDriverOptions) driver.Connector {
+	d := &sqlDriver{Driver: c.Driver(), logger: &sqlLogger{opts: opts}}
+	return &sqlConnector{connector: c, driver: d, logger: d.logger}
+}
+
+type sqlLogger struct {
+	fastCount uint64 // first field to keep 64-bit alignment for atomic access on 32-bit platforms
+	opts      SQLDriverOptions
+}
+
+func (s *sqlLogger) log(ctx context.Context, op, query string, args []driver.NamedValue, txID string, start time.Time, res driver.Result, err error) {
+	duration := time.Since(start)
+
+	payload := &log.DBQueryPayload{
+		Operation:    op,
+		Query:        query,
+		ArgCount:     len(args),
+		RowsAffected: -1,
+		Duration:     duration.Seconds(),
+		TxID:         txID,
+		Slow:         s.opts.SlowThreshold > 0 && duration >= s.opts.SlowThreshold,
+	}
+
+	if err == nil && !payload.Slow && s.opts.SampleFastQueries > 1 &&
+		atomic.AddUint64(&s.fastCount, 1)%uint64(s.opts.SampleFastQueries) != 1 {
+		return
+	}
+
+	if s.opts.LogArgs {
+		var redact []string
+		if sukiLogger != nil {
+			redact = sukiLogger.currentConfig().Redact
+		}
+
+		payload.Args = make([]string, len(args))
+		for i, a := range args {
+			if s.redactArg(query, a, redact) {
+				payload.Args[i] = zap_logger.RedactedValue
+				continue
+			}
+			payload.Args[i] = formatSQLArg(a.Value)
+		}
+	}
+
+	if res != nil {
+		if n, rowsErr := res.RowsAffected(); rowsErr == nil {
+			payload.RowsAffected = n
+		}
+	}
+
+	l := DBQuery("DBQuery executed", payload)
+	switch {
+	case err != nil:
+		l = l.SetMessage("DBQuery failed").SetLevel(level.Error).WithError(err)
+	case payload.Slow:
+		l = l.SetMessage("DBQuery slow").SetLevel(level.Warn).SetAlert(true)
+	}
+
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		l = l.WithTracing(sc)
+	}
+
+	l.CallerSkip(sqlCallerSkip()).Write()
+}
+
+func (s *sqlLogger) redactArg(query string, arg driver.NamedValue, keys []string) bool {
+	if arg.Name != "" {
+		for _, k := range keys {
+			if strings.EqualFold(k, arg.Name) {
+				return true
+			}
+		}
+	}
+
+	return s.opts.RedactArg != nil && s.opts.RedactArg(query, arg)
+}
+
+// sqlPackage is the import path of this package, its sql* wrappers are skipped like database/sql.
+var sqlPackage = reflect.TypeOf(sqlLogger{}).PkgPath()
+
+// sqlCallerSkip returns the number of frames from sqlLogger.log to the application code calling database/sql,
+// so the entry reports the query site instead of the driver wrapper.
+func sqlCallerSkip() int {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	skip := 0
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "database/sql.") && !strings.HasPrefix(frame.Function, sqlPackage+".(*sql") || !more {
+			return skip
+		}
+		skip++
+	}
+}
+
+func formatSQLArg(v driver.Value) string {
+	if b, ok := v.([]byte); ok {
+		return fmt.Sprintf("[]byte(%d)", len(b))
+	}
+
+	return fmt.Sprint(v)
+}
+
+func namedValuesToValues(named []driver.NamedValue) ([]driver.Value, error) {
+	args := make([]driver.Value, len(named))
+	for i, n := range named {
+		if n.Name != "" {
+			return nil, errors.New("slog: driver does not support named parameters")
+		}
+		args[i] = n.Value
+	}
+	return args, nil
+}
+
+type sqlDriver struct {
+	driver.Driver
+	logger *sqlLogger
+}
+
+func (d *sqlDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.Driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqlConn{Conn: conn, logger: d.logger}, nil
+}
+
+func (d *sqlDriver) OpenConnector(name string) (driver.Connector, error) {
+	dc, ok := d.Driver.(driver.DriverContext)
+	if !ok {
+		return &dsnConnector{name: name, driver: d}, nil
+	}
+
+	c, err := dc.OpenConnector(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqlConnector{connector: c, driver: d, logger: d.logger}, nil
+}
+
+type dsnConnector struct {
+	name   string
+	driver *sqlDriver
+}
+
+func (c *dsnConnector) Connect(_ context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.name)
+}
+
+func (c *dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+type sqlConnector struct {
+	connector driver.Connector
+	driver    driver.Driver
+	logger    *sqlLogger
+}
+
+func (c *sqlConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqlConn{Conn: conn, logger: c.logger}, nil
+}
+
+func (c *sqlConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// sqlConn is used by a single goroutine at a time as guaranteed by database/sql,
+// so the current transaction ID needs no locking.
+type sqlConn struct {
+	driver.Conn
+	logger *sqlLogger
+	txID   string
+}
+
+func (c *sqlConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *sqlConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var stmt driver.Stmt
+	var err error
+	if p, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = p.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqlStmt{Stmt: stmt, conn: c, query: query}, nil
+}
+
+func (c *sqlConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *sqlConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	var tx driver.Tx
+	var err error
+	if b, ok := c.Conn.(driver.ConnBeginTx); ok {
+		tx, err = b.BeginTx(ctx, opts)
+	} else {
+		if opts.Isolation != 0 || opts.ReadOnly {
+			return nil, errors.New("slog: driver does not support transaction options")
+		}
+		tx, err = c.Conn.Begin()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.txID = randomID()
+	return &sqlTx{Tx: tx, conn: c}, nil
+}
+
+func (c *sqlConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	start := time.Now()
+	res, err := execer.ExecContext(ctx, query, args)
+	if err == driver.ErrSkip {
+		return nil, err
+	}
+
+	c.logger.log(ctx, "exec", query, args, c.txID, start, res, err)
+	return res, err
+}
+
+func (c *sqlConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	if err == driver.ErrSkip {
+		return nil, err
+	}
+
+	c.logger.log(ctx, "query", query, args, c.txID, start, nil, err)
+	return rows, err
+}
+
+func (c *sqlConn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *sqlConn) ResetSession(ctx context.Context) error {
+	if r, ok := c.Conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *sqlConn) IsValid() bool {
+	if v, ok := c.Conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+func (c *sqlConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if n, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return n.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+type sqlTx struct {
+	driver.Tx
+	conn *sqlConn
+}
+
+func (t *sqlTx) Commit() error {
+	t.conn.txID = ""
+	return t.Tx.Commit()
+}
+
+func (t *sqlTx) Rollback() error {
+	t.conn.txID = ""
+	return t.Tx.Rollback()
+}
+
+type sqlStmt struct {
+	driver.Stmt
+	conn  *sqlConn
+	query string
+}
+
+func (s *sqlStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	start := time.Now()
+
+	var res driver.Result
+	var err error
+	if e, ok := s.Stmt.(driver.StmtExecContext); ok {
+		res, err = e.ExecContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = namedValuesToValues(args); err == nil {
+			res, err = s.Stmt.Exec(values)
+		}
+	}
+
+	s.conn.logger.log(ctx, "exec", s.query, args, s.conn.txID, start, res, err)
+	return res, err
+}
+
+func (s *sqlStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	start := time.Now()
+
+	var rows driver.Rows
+	var err error
+	if q, ok := s.Stmt.(driver.StmtQueryContext); ok {
+		rows, err = q.QueryContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = namedValuesToValues(args); err == nil {
+			rows, err = s.Stmt.Query(values)
+		}
+	}
+
+	s.conn.logger.log(ctx, "query", s.query, args, s.conn.txID, start, nil, err)
+	return rows, err
+}
+
+func (s *sqlStmt) CheckNamedValue(nv *driver.NamedValue) error {
+	if n, ok := s.Stmt.(driver.NamedValueChecker); ok {
+		return n.CheckNamedValue(nv)
+	}
+	return s.conn.CheckNamedValue(nv)
+}
+
+func (s *sqlStmt) ColumnConverter(idx int) driver.ValueConverter {
+	if c, ok := s.Stmt.(driver.ColumnConverter); ok {
+		return c.ColumnConverter(idx)
+	}
+	return driver.DefaultParameterConverter
+}
@@ -0,0 +1,169 @@
+package slog
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/config"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSQLDriver is a minimal driver, queries starting with "sleep" take 20ms and queries starting with "fail" return an error.
+type fakeSQLDriver struct{}
+
+func (fakeSQLDriver) Open(string) (driver.Conn, error) { return &fakeSQLConn{}, nil }
+
+type fakeSQLConnector struct{}
+
+func (fakeSQLConnector) Connect(context.Context) (driver.Conn, error) { return &fakeSQLConn{}, nil }
+func (fakeSQLConnector) Driver() driver.Driver                        { return fakeSQLDriver{} }
+
+type fakeSQLConn struct{}
+
+func (c *fakeSQLConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeSQLStmt{query: query}, nil
+}
+func (c *fakeSQLConn) Close() error              { return nil }
+func (c *fakeSQLConn) Begin() (driver.Tx, error) { return fakeSQLTx{}, nil }
+
+func (c *fakeSQLConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	if strings.HasPrefix(query, "sleep") {
+		time.Sleep(20 * time.Millisecond)
+	}
+	if strings.HasPrefix(query, "fail") {
+		return nil, errors.New("syntax error")
+	}
+	return driver.RowsAffected(3), nil
+}
+
+type fakeSQLStmt struct{ query string }
+
+func (s *fakeSQLStmt) Close() error  { return nil }
+func (s *fakeSQLStmt) NumInput() int { return -1 }
+func (s *fakeSQLStmt) Exec([]driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+func (s *fakeSQLStmt) Query([]driver.Value) (driver.Rows, error) { return &fakeSQLRows{}, nil }
+
+type fakeSQLRows struct{}
+
+func (r *fakeSQLRows) Columns() []string         { return []string{"id"} }
+func (r *fakeSQLRows) Close() error              { return nil }
+func (r *fakeSQLRows) Next([]driver.Value) error { return io.EOF }
+
+type fakeSQLTx struct{}
+
+func (fakeSQLTx) Commit() error   { return nil }
+func (fakeSQLTx) Rollback() error { return nil }
+
+var registerFakeSQL sync.Once
+
+func dbQueryPayload(line map[string]any) map[string]any {
+	return line["data"].(map[string]any)["db_query"].(map[string]any)
+}
+
+func TestWrapSQLDriver(t *testing.T) {
+	buf := useBufferLogger(t, config.Config{AppName: "app"})
+
+	// sql.Register panics on a second call, e.g. under go test -count=2.
+	registerFakeSQL.Do(func() {
+		sql.Register("fake+slog", WrapSQLDriver(fakeSQLDriver{}, SQLDriverOptions{
+			SlowThreshold: 10 * time.Millisecond,
+		}))
+	})
+	db, err := sql.Open("fake+slog", "")
+	assert.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+
+	_, err = db.ExecContext(ctx, "update orders set status = ? where id = ?", "paid", 42)
+	assert.NoError(t, err)
+
+	rows, err := db.QueryContext(ctx, "select id from orders where id = ?", 42)
+	assert.NoError(t, err)
+	rows.Close()
+
+	tx, err := db.BeginTx(ctx, nil)
+	assert.NoError(t, err)
+	_, err = tx.ExecContext(ctx, "sleep")
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Commit())
+
+	_, err = db.ExecContext(ctx, "fail")
+	assert.Error(t, err)
+
+	lines := decodeLines(t, buf)
+	assert.Len(t, lines, 4)
+
+	exec := dbQueryPayload(lines[0])
+	assert.Equal(t, "db.query", lines[0]["log_type"])
+	assert.Equal(t, "exec", exec["operation"])
+	assert.Equal(t, float64(2), exec["arg_count"])
+	assert.Equal(t, float64(3), exec["rows_affected"])
+	assert.NotContains(t, exec, "args")
+	assert.NotContains(t, exec, "tx_id")
+
+	query := dbQueryPayload(lines[1])
+	assert.Equal(t, "query", query["operation"])
+	assert.Equal(t, float64(-1), query["rows_affected"])
+
+	slow := dbQueryPayload(lines[2])
+	assert.Equal(t, "warn", lines[2]["level"])
+	assert.Equal(t, float64(1), lines[2]["alert"])
+	assert.Equal(t, true, slow["slow"])
+	assert.Len(t, slow["tx_id"], 16)
+
+	assert.Equal(t, "error", lines[3]["level"])
+	assert.Equal(t, "syntax error", lines[3]["data"].(map[string]any)["error"])
+}
+
+func TestWrapSQLConnector_ArgsAndSampling(t *testing.T) {
+	buf := useBufferLogger(t, config.Config{AppName: "app"})
+
+	db := sql.OpenDB(WrapSQLConnector(fakeSQLConnector{}, SQLDriverOptions{LogArgs: true, SampleFastQueries: 3}))
+	defer db.Close()
+
+	for i := 0; i < 6; i++ {
+		_, err := db.Exec("insert into files values (?, ?)", i, []byte("blob"))
+		assert.NoError(t, err)
+	}
+
+	lines := decodeLines(t, buf)
+	assert.Len(t, lines, 2)
+	assert.Equal(t, []any{"0", "[]byte(4)"}, dbQueryPayload(lines[0])["args"])
+	assert.Equal(t, []any{"3", "[]byte(4)"}, dbQueryPayload(lines[1])["args"])
+}
+
+func TestWrapSQLConnector_RedactAndCaller(t *testing.T) {
+	buf := useBufferLogger(t, config.Config{AppName: "app", Redact: []string{"password"}})
+	sukiLogger.zapInstance = sukiLogger.zapInstance.WithOptions(zap.AddCaller(), zap.AddCallerSkip(2))
+
+	db := sql.OpenDB(WrapSQLConnector(fakeSQLConnector{}, SQLDriverOptions{
+		LogArgs: true,
+		RedactArg: func(query string, arg driver.NamedValue) bool {
+			return strings.HasPrefix(query, "insert into cards") && arg.Ordinal == 2
+		},
+	}))
+	defer db.Close()
+
+	_, err := db.Exec("insert into users values (@name, @password)", sql.Named("name", "somchai"), sql.Named("password", "secret"))
+	assert.NoError(t, err)
+	_, err = db.Exec("insert into cards values (?, ?)", 1, "4111111111111111")
+	assert.NoError(t, err)
+
+	lines := decodeLines(t, buf)
+	assert.Len(t, lines, 2)
+	assert.Equal(t, []any{"somchai", "[REDACTED]"}, dbQueryPayload(lines[0])["args"])
+	assert.Equal(t, []any{"1", "[REDACTED]"}, dbQueryPayload(lines[1])["args"])
+
+	// The caller is the query site, not the driver wrapper or database/sql.
+	assert.Contains(t, lines[0]["caller"], "sql_driver_test.go")
+}
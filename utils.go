@@ -0,0 +1,13 @@
+package slog
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// randomID returns a random 16 character hex string.
+func randomID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,305 @@
+// Package v1compat implements the option-style v1 API on top of the v2 logger, so a service can switch its import path
+// first and move its call sites to the v2 builders later:
+//
+//	import slog "github.com/Sellsuki/sellsuki-go-logger/v2/v1compat"
+//
+//	slog.L().Info("Hello World", slog.Any("Yeet", 1), slog.WithTracing("trace_id", "span_id"))
+//
+// Entries are written in the v2 format through the logger created by slog.Init, with the same hooks and sinks.
+package v1compat
+
+import (
+	"errors"
+	slog "github.com/Sellsuki/sellsuki-go-logger/v2"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/config"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/log"
+	"strings"
+	"time"
+)
+
+const (
+	LevelDebug = level.Debug
+	LevelInfo  = level.Info
+	LevelWarn  = level.Warn
+	LevelError = level.Error
+	LevelPanic = level.Panic
+	LevelFatal = level.Fatal
+)
+
+const (
+	ActionCreate = log.EventActionCreate
+	ActionUpdate = log.EventActionUpdate
+	ActionDelete = log.EventActionDelete
+
+	ResultSuccess    = log.EventResultSuccess
+	ResultCompensate = log.EventResultCompensate
+)
+
+// NewProductionConfig returns the defaults of the v2 logger, see slog.NewProductionConfig.
+func NewProductionConfig() config.Config {
+	return slog.NewProductionConfig()
+}
+
+// Logger writes v1 style entries, use L to get it.
+type Logger struct{}
+
+var logger = &Logger{}
+
+// L returns the logger, it creates the v2 logger with its defaults when slog.Init was not called yet.
+// A later slog.Init still applies its configuration, see slog.InitDefault.
+func L() *Logger {
+	slog.InitDefault()
+	return logger
+}
+
+// Configure replaces the configuration of the logger, start from NewProductionConfig to keep the defaults.
+func (l *Logger) Configure(cfg config.Config) error {
+	slog.Init(cfg)
+	return slog.Reload(cfg)
+}
+
+func (l *Logger) Debug(msg string, opts ...Option) {
+	l.writeEntry(slog.Debug(msg), newEntry(opts))
+}
+
+func (l *Logger) Info(msg string, opts ...Option) {
+	l.writeEntry(slog.Info(msg), newEntry(opts))
+}
+
+func (l *Logger) Warn(msg string, opts ...Option) {
+	l.writeEntry(slog.Warn(msg), newEntry(opts))
+}
+
+func (l *Logger) Error(msg string, opts ...Option) {
+	l.writeEntry(slog.Error(msg), newEntry(opts))
+}
+
+// Panic writes the entry and then panics.
+func (l *Logger) Panic(msg string, opts ...Option) {
+	l.writeEntry(slog.Panic(msg), newEntry(opts))
+}
+
+// Fatal writes the entry and then exits the process.
+func (l *Logger) Fatal(msg string, opts ...Option) {
+	l.writeEntry(slog.Fatal(msg), newEntry(opts))
+}
+
+// RequestHTTP writes a handler.http entry with the request and response of WithHTTPRequest and WithHTTPResponse.
+func (l *Logger) RequestHTTP(msg string, opts ...Option) {
+	e := newEntry(opts)
+	if e.tracing != nil && e.tracing.requestID != "" {
+		if e.httpRequest != nil && e.httpRequest.RequestID == "" {
+			e.httpRequest.RequestID = e.tracing.requestID
+		}
+		if e.httpResponse != nil && e.httpResponse.RequestID == "" {
+			e.httpResponse.RequestID = e.tracing.requestID
+		}
+	}
+
+	l.writeEntry(slog.HTTP(msg, e.httpRequest, e.httpResponse), e)
+}
+
+// RequestKafka writes a handler.kafka entry with the message and result of WithKafkaMessage and WithKafkaResult.
+func (l *Logger) RequestKafka(msg string, opts ...Option) {
+	e := newEntry(opts)
+	l.writeEntry(slog.Kafka(msg, e.kafkaMessage, e.kafkaResult), e)
+}
+
+// Event writes an event entry with the payload of WithEvent.
+func (l *Logger) Event(msg string, opts ...Option) {
+	e := newEntry(opts)
+	l.writeEntry(slog.Event(msg, e.event), e)
+}
+
+// writeEntry applies the options to the entry and writes it, it is called by the Logger methods directly
+// so the entry reports the caller of the method.
+func (l *Logger) writeEntry(b log.Log, e *entry) {
+	for _, f := range e.fields {
+		b = b.WithAppData(f.key, f.value)
+	}
+
+	if e.alert {
+		b = b.SetAlert(true)
+	}
+
+	// Several errors, e.g. of WithHTTPResponse and WithError, are joined so none of them is lost.
+	if len(e.errors) > 0 {
+		var names, stackTraces []string
+		for _, info := range e.errors {
+			names = append(names, info.Name)
+			if info.StackTrace != "" {
+				stackTraces = append(stackTraces, info.StackTrace)
+			}
+		}
+
+		b = b.WithError(errors.New(strings.Join(names, "; ")))
+		if len(stackTraces) > 0 {
+			b = withField(b, "stack_trace", strings.Join(stackTraces, "\n\n"))
+		}
+	}
+
+	if e.tracing != nil {
+		tracing := map[string]string{"trace_id": e.tracing.traceID, "span_id": e.tracing.spanID}
+		if e.tracing.requestID != "" {
+			tracing["request_id"] = e.tracing.requestID
+		}
+		b = withField(b, "tracing", tracing)
+	}
+
+	b.CallerSkip(2).Write()
+}
+
+// withField adds a data field through the v2 builder, which implements WithField.
+func withField(b log.Log, key string, value any) log.Log {
+	if f, ok := b.(interface {
+		WithField(key string, value any) log.Log
+	}); ok {
+		return f.WithField(key, value)
+	}
+	return b
+}
+
+// Option adds data to a v1 entry.
+type Option interface {
+	apply(e *entry)
+}
+
+type optionFunc func(e *entry)
+
+func (f optionFunc) apply(e *entry) {
+	f(e)
+}
+
+type field struct {
+	key   string
+	value any
+}
+
+type tracing struct {
+	traceID   string
+	spanID    string
+	requestID string
+}
+
+// entry collects the options of a v1 call.
+type entry struct {
+	fields       []field
+	alert        bool
+	errors       []ErrorInfo
+	tracing      *tracing
+	httpRequest  *log.HTTPRequestPayload
+	httpResponse *log.HTTPResponsePayload
+	kafkaMessage *log.KafkaMessagePayload
+	kafkaResult  *log.KafkaResultPayload
+	event        log.EventPayload
+}
+
+func newEntry(opts []Option) *entry {
+	e := &entry{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt.apply(e)
+		}
+	}
+	return e
+}
+
+// Any adds a value to the app data of the entry, written under data[AppName][key].
+func Any(key string, value any) Option {
+	return optionFunc(func(e *entry) { e.fields = append(e.fields, field{key, value}) })
+}
+
+// WithTracing adds the trace and span IDs, and the optional request ID, as data "tracing".
+func WithTracing(traceID string, spanID string, requestID ...string) Option {
+	return optionFunc(func(e *entry) {
+		e.tracing = &tracing{traceID: traceID, spanID: spanID}
+		if len(requestID) > 0 {
+			e.tracing.requestID = requestID[0]
+		}
+	})
+}
+
+// LogOption holds the v1 entry settings, Alert 1 marks the entry as an alert.
+type LogOption struct {
+	Alert int
+}
+
+func WithOption(opt LogOption) Option {
+	return optionFunc(func(e *entry) { e.alert = opt.Alert == 1 })
+}
+
+// ErrorInfo is the v1 error of an entry, Name is written as data "error" and StackTrace as data "stack_trace".
+// The names of several errors are joined with "; " and their stack traces with a blank line.
+// Caller is not written, v2 entries report the caller of the log call.
+type ErrorInfo struct {
+	Name       string
+	Caller     string
+	StackTrace string
+}
+
+func (i ErrorInfo) apply(e *entry) {
+	e.errors = append(e.errors, i)
+}
+
+// WithError returns an error to pass to WithHTTPResponse, WithKafkaResult or directly to a Logger method.
+func WithError(name string, caller string, stackTrace string) ErrorInfo {
+	return ErrorInfo{Name: name, Caller: caller, StackTrace: stackTrace}
+}
+
+func WithHTTPRequest(method string, path string, remoteIP string, headers map[string]string, params map[string]string, query map[string]string, body string) Option {
+	return optionFunc(func(e *entry) {
+		e.httpRequest = &log.HTTPRequestPayload{
+			Method:   method,
+			Path:     path,
+			RemoteIP: remoteIP,
+			Headers:  headers,
+			Params:   params,
+			Query:    query,
+			Body:     body,
+		}
+	})
+}
+
+// WithHTTPResponse adds the response of the request, duration is in seconds.
+func WithHTTPResponse(status int64, duration float64, body string, errs ...ErrorInfo) Option {
+	return optionFunc(func(e *entry) {
+		e.httpResponse = &log.HTTPResponsePayload{Status: status, Duration: duration, Body: body}
+		e.errors = append(e.errors, errs...)
+	})
+}
+
+func WithKafkaMessage(topic string, partition int64, offset int64, headers map[string]string, key string, payload string, timestamp time.Time) Option {
+	return optionFunc(func(e *entry) {
+		e.kafkaMessage = &log.KafkaMessagePayload{
+			Topic:     topic,
+			Partition: partition,
+			Offset:    offset,
+			Headers:   headers,
+			Key:       key,
+			Payload:   payload,
+			Timestamp: timestamp,
+		}
+	})
+}
+
+// WithKafkaResult adds the result of processing the message, duration is in seconds.
+func WithKafkaResult(duration float64, errs ...ErrorInfo) Option {
+	return optionFunc(func(e *entry) {
+		e.kafkaResult = &log.KafkaResultPayload{Duration: duration}
+		e.errors = append(e.errors, errs...)
+	})
+}
+
+// WithEvent sets the payload of an Event entry, data is the raw JSON of the event.
+func WithEvent(entity string, action log.EventAction, result log.EventResult, data string, referenceID string) Option {
+	return optionFunc(func(e *entry) {
+		e.event = log.EventPayload{
+			Entity:      entity,
+			ReferenceID: referenceID,
+			Action:      action,
+			Result:      result,
+			DataJSON:    data,
+		}
+	})
+}
@@ -0,0 +1,149 @@
+package v1compat
+
+import (
+	"errors"
+	slog "github.com/Sellsuki/sellsuki-go-logger/v2"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/config"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/log"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/zap_logger"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+var (
+	hookOnce sync.Once
+	captured []*zap_logger.Entry
+)
+
+// capture returns the entries written by fn, they are dropped instead of being written to stdout.
+func capture(t *testing.T, fn func()) []*zap_logger.Entry {
+	hookOnce.Do(func() {
+		assert.NoError(t, L().Configure(config.Config{AppName: "order-service", LogLevel: LevelDebug}))
+		slog.AddHook(func(e *zap_logger.Entry) bool {
+			captured = append(captured, e)
+			return false
+		})
+	})
+
+	captured = nil
+	fn()
+	return captured
+}
+
+func TestLogger_Info(t *testing.T) {
+	entries := capture(t, func() {
+		L().Info("Hello World", Any("Yeet", 1), WithTracing("a", "b", "c"), WithOption(LogOption{Alert: 1}))
+	})
+
+	assert.Len(t, entries, 1)
+	e := entries[0]
+	assert.Equal(t, zap_logger.TypeApplication, e.Type)
+	assert.Equal(t, level.Info, e.Level)
+	assert.Equal(t, "Hello World", e.Message)
+	assert.True(t, e.Alert)
+	assert.Equal(t, map[string]any{"Yeet": 1}, e.AppFields)
+	assert.Equal(t, map[string]string{"trace_id": "a", "span_id": "b", "request_id": "c"}, e.Data["tracing"])
+}
+
+func TestLogger_Levels(t *testing.T) {
+	entries := capture(t, func() {
+		L().Debug("debug")
+		L().Warn("warn")
+		L().Error("error", WithError("item_not_found", "/dodge/wow.go:35", "some stack trace here"))
+	})
+
+	assert.Len(t, entries, 3)
+	assert.Equal(t, level.Debug, entries[0].Level)
+	assert.Equal(t, level.Warn, entries[1].Level)
+	assert.Equal(t, level.Error, entries[2].Level)
+	assert.Equal(t, errors.New("item_not_found"), entries[2].Err)
+	assert.Equal(t, "item_not_found", entries[2].Data["error"])
+	assert.Equal(t, "some stack trace here", entries[2].Data["stack_trace"])
+}
+
+func TestLogger_Errors(t *testing.T) {
+	entries := capture(t, func() {
+		L().Error("failed",
+			WithError("timeout", "", "stack a"),
+			WithKafkaResult(0.1, WithError("retry_exhausted", "", ""), WithError("dlq_failed", "", "stack b")),
+		)
+	})
+
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "timeout; retry_exhausted; dlq_failed", entries[0].Data["error"])
+	assert.Equal(t, "stack a\n\nstack b", entries[0].Data["stack_trace"])
+}
+
+func TestLogger_RequestHTTP(t *testing.T) {
+	entries := capture(t, func() {
+		L().RequestHTTP("such wow",
+			WithHTTPRequest("POST", "/dodge/wow", "127.0.0.1",
+				map[string]string{"Content-Type": "application/json"},
+				map[string]string{"user_id": "777"},
+				map[string]string{"keyword": "yikes"},
+				`{"such": "wow"}`,
+			),
+			WithHTTPResponse(200, 0.0167777, `{"such": "wow"}`, WithError("item_not_found", "/dodge/wow.go:35", "")),
+			WithTracing("trace_id", "span_id", "request_id"),
+		)
+	})
+
+	assert.Len(t, entries, 1)
+	e := entries[0]
+	assert.Equal(t, zap_logger.TypeHandlerHTTP, e.Type)
+	assert.Equal(t, &log.HTTPRequestPayload{
+		Method:    "POST",
+		Path:      "/dodge/wow",
+		RemoteIP:  "127.0.0.1",
+		Headers:   map[string]string{"Content-Type": "application/json"},
+		Params:    map[string]string{"user_id": "777"},
+		Query:     map[string]string{"keyword": "yikes"},
+		Body:      `{"such": "wow"}`,
+		RequestID: "request_id",
+	}, e.Data["http_request"])
+	assert.Equal(t, &log.HTTPResponsePayload{Status: 200, Duration: 0.0167777, Body: `{"such": "wow"}`, RequestID: "request_id"}, e.Data["http_response"])
+	assert.Equal(t, "item_not_found", e.Data["error"])
+	assert.NotContains(t, e.Data, "stack_trace")
+}
+
+func TestLogger_RequestKafka(t *testing.T) {
+	ts := time.Date(2023, 11, 9, 14, 48, 14, 0, time.UTC)
+	entries := capture(t, func() {
+		L().RequestKafka("write something about kafka",
+			WithKafkaMessage("topic.name.here", 0, 500, map[string]string{"header_key": "header_value"}, "kafka_key", "kafka payload here", ts),
+			WithKafkaResult(0.016777),
+		)
+	})
+
+	assert.Len(t, entries, 1)
+	e := entries[0]
+	assert.Equal(t, zap_logger.TypeHandlerKafka, e.Type)
+	assert.Equal(t, &log.KafkaMessagePayload{
+		Topic:     "topic.name.here",
+		Offset:    500,
+		Headers:   map[string]string{"header_key": "header_value"},
+		Key:       "kafka_key",
+		Payload:   "kafka payload here",
+		Timestamp: ts,
+	}, e.Data["kafka_message"])
+	assert.Equal(t, &log.KafkaResultPayload{Duration: 0.016777}, e.Data["kafka_result"])
+}
+
+func TestLogger_Event(t *testing.T) {
+	entries := capture(t, func() {
+		L().Event("event message", WithEvent("order", ActionCreate, ResultSuccess, `{"id":1}`, "ref_id"))
+	})
+
+	assert.Len(t, entries, 1)
+	assert.Equal(t, zap_logger.TypeEvent, entries[0].Type)
+	assert.Equal(t, log.EventPayload{
+		Entity:      "order",
+		ReferenceID: "ref_id",
+		Action:      log.EventActionCreate,
+		Result:      log.EventResultSuccess,
+		DataJSON:    `{"id":1}`,
+	}, entries[0].Data["event"])
+}
@@ -0,0 +1,70 @@
+package zap_logger
+
+import (
+	"github.com/Sellsuki/sellsuki-go-logger/v2/log"
+	"go.uber.org/zap"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	helpers     sync.Map // map[string]struct{} of the function names marked with MarkHelper
+	helperCount int32
+)
+
+// MarkHelper marks the function skip frames above the caller of MarkHelper as a logging helper,
+// the caller reported for entries written from a helper is the first function above it that is not a helper.
+// Like testing.T.Helper, it is meant to be called at the start of the helper.
+func MarkHelper(skip int) {
+	if _, loaded := helpers.LoadOrStore(callerFunction(skip+1), struct{}{}); !loaded {
+		atomic.AddInt32(&helperCount, 1)
+	}
+}
+
+// resolveCaller returns the number of frames to skip above the caller skip frames above its own caller,
+// extra frames plus the helpers found above them, and the function name of the resulting caller.
+func resolveCaller(skip int, extra int) (int, string) {
+	if extra == 0 && atomic.LoadInt32(&helperCount) == 0 {
+		return 0, callerFunction(skip + 1)
+	}
+
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(skip+2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	skipped := 0
+	for {
+		frame, more := frames.Next()
+		if skipped >= extra {
+			if _, ok := helpers.Load(frame.Function); !ok || !more {
+				return skipped, frame.Function
+			}
+		}
+		if !more {
+			return skipped, frame.Function
+		}
+		skipped++
+	}
+}
+
+// callerSkipper is implemented by outputs that can report the caller extra frames higher for one entry.
+type callerSkipper interface {
+	WithCallerSkip(skip int) log.ZapLogger
+}
+
+// withCallerSkip returns the output reporting the caller skip frames higher, or the output itself when it cannot.
+func withCallerSkip(out log.ZapLogger, skip int) log.ZapLogger {
+	if skip == 0 {
+		return out
+	}
+
+	switch t := out.(type) {
+	case *zap.Logger:
+		return t.WithOptions(zap.AddCallerSkip(skip))
+	case callerSkipper:
+		return t.WithCallerSkip(skip)
+	default:
+		return out
+	}
+}
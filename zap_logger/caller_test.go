@@ -0,0 +1,80 @@
+package zap_logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/config"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// newCallerLogger returns a zap logger reporting the caller of Write with its function name.
+func newCallerLogger(buf *bytes.Buffer) *zap.Logger {
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.FunctionKey = "function"
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(encoderConfig), zapcore.AddSync(buf), zap.DebugLevel)
+	return zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1))
+}
+
+func callerOf(t *testing.T, buf *bytes.Buffer) (caller string, function string) {
+	var m map[string]any
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &m))
+	buf.Reset()
+	caller, _ = m["caller"].(string)
+	function, _ = m["function"].(string)
+	return caller, function
+}
+
+func here() string {
+	_, file, line, _ := runtime.Caller(1)
+	return fmt.Sprintf("%s/%s:%d", filepath.Base(filepath.Dir(file)), filepath.Base(file), line-1)
+}
+
+func logWrapper(logger *zap.Logger, msg string) {
+	New(logger, config.Config{}, level.Info, TypeApplication, msg).CallerSkip(1).Write()
+}
+
+func logHelper(logger *zap.Logger, msg string) {
+	MarkHelper(0)
+	New(logger, config.Config{}, level.Info, TypeApplication, msg).Write()
+}
+
+func logNestedHelper(logger *zap.Logger, msg string) {
+	MarkHelper(0)
+	logHelper(logger, msg)
+}
+
+func TestCaller(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newCallerLogger(&buf)
+
+	New(logger, config.Config{}, level.Info, TypeApplication, "direct").Write()
+	want := here()
+	caller, function := callerOf(t, &buf)
+	assert.Equal(t, want, caller)
+	assert.Equal(t, "github.com/Sellsuki/sellsuki-go-logger/v2/zap_logger.TestCaller", function)
+
+	logWrapper(logger, "entry skip")
+	want = here()
+	caller, _ = callerOf(t, &buf)
+	assert.Equal(t, want, caller)
+
+	logNestedHelper(logger, "helper")
+	want = here()
+	caller, function = callerOf(t, &buf)
+	assert.Equal(t, want, caller)
+	assert.Equal(t, "github.com/Sellsuki/sellsuki-go-logger/v2/zap_logger.TestCaller", function)
+
+	func() {
+		New(logger, config.Config{CallerSkip: 1}, level.Info, TypeApplication, "config skip").Write()
+	}()
+	want = here()
+	caller, _ = callerOf(t, &buf)
+	assert.Equal(t, want, caller)
+}
@@ -0,0 +1,81 @@
+package zap_logger
+
+import (
+	"github.com/Sellsuki/sellsuki-go-logger/v2/config"
+	"go.uber.org/zap"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"sync"
+)
+
+type hostMetadata struct {
+	Name string `json:"name,omitempty"`
+	PID  int    `json:"pid"`
+}
+
+type k8sMetadata struct {
+	Pod       string `json:"pod,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Node      string `json:"node,omitempty"`
+}
+
+type buildMetadata struct {
+	Revision  string `json:"revision,omitempty"`
+	GoVersion string `json:"go_version"`
+}
+
+type processMetadata struct {
+	host  hostMetadata
+	k8s   *k8sMetadata // k8s is nil outside of Kubernetes.
+	build buildMetadata
+}
+
+// process is read once, the metadata of the process does not change while it runs.
+var (
+	processOnce sync.Once
+	process     processMetadata
+)
+
+func readProcessMetadata() processMetadata {
+	var m processMetadata
+	m.host.Name, _ = os.Hostname()
+	m.host.PID = os.Getpid()
+
+	k8s := k8sMetadata{
+		Pod:       os.Getenv("POD_NAME"),
+		Namespace: os.Getenv("POD_NAMESPACE"),
+		Node:      os.Getenv("NODE_NAME"),
+	}
+	if k8s != (k8sMetadata{}) {
+		m.k8s = &k8s
+	}
+
+	m.build.GoVersion = runtime.Version()
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range info.Settings {
+			if s.Key == "vcs.revision" {
+				m.build.Revision = s.Value
+			}
+		}
+	}
+
+	return m
+}
+
+// enrichFields returns the metadata fields added by config.EnrichConfig.
+func enrichFields(c config.EnrichConfig, m processMetadata) []zap.Field {
+	var f []zap.Field
+	if c.Environment != "" {
+		f = append(f, zap.String("environment", c.Environment))
+	}
+	if c.Region != "" {
+		f = append(f, zap.String("region", c.Region))
+	}
+
+	f = append(f, zap.Any("host", m.host))
+	if m.k8s != nil {
+		f = append(f, zap.Any("k8s", *m.k8s))
+	}
+	return append(f, zap.Any("build", m.build))
+}
@@ -0,0 +1,69 @@
+package zap_logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/config"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"os"
+	"runtime"
+	"testing"
+)
+
+func TestReadProcessMetadata(t *testing.T) {
+	t.Setenv("POD_NAME", "order-service-7d9f8b-x2k4q")
+	t.Setenv("POD_NAMESPACE", "shop")
+	t.Setenv("NODE_NAME", "")
+
+	m := readProcessMetadata()
+	hostname, _ := os.Hostname()
+	assert.Equal(t, hostMetadata{Name: hostname, PID: os.Getpid()}, m.host)
+	assert.Equal(t, &k8sMetadata{Pod: "order-service-7d9f8b-x2k4q", Namespace: "shop"}, m.k8s)
+	assert.Equal(t, runtime.Version(), m.build.GoVersion)
+
+	t.Setenv("POD_NAME", "")
+	t.Setenv("POD_NAMESPACE", "")
+	assert.Nil(t, readProcessMetadata().k8s, "outside of Kubernetes")
+}
+
+func TestEnrichFields(t *testing.T) {
+	m := processMetadata{
+		host:  hostMetadata{Name: "web-1", PID: 42},
+		k8s:   &k8sMetadata{Pod: "order-service-7d9f8b-x2k4q", Namespace: "shop", Node: "node-1"},
+		build: buildMetadata{Revision: "4f2c1e0", GoVersion: "go1.21.5"},
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range enrichFields(config.EnrichConfig{Enabled: true, Environment: "production"}, m) {
+		f.AddTo(enc)
+	}
+	b, _ := json.Marshal(enc.Fields)
+
+	assert.JSONEq(t, `{
+		"environment": "production",
+		"host": {"name": "web-1", "pid": 42},
+		"k8s": {"pod": "order-service-7d9f8b-x2k4q", "namespace": "shop", "node": "node-1"},
+		"build": {"revision": "4f2c1e0", "go_version": "go1.21.5"}
+	}`, string(b))
+}
+
+func TestBase_Write_Enrich(t *testing.T) {
+	var buf bytes.Buffer
+
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.EncodeTime = FixedTimeEncoder
+	logger := zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(encoderConfig), zapcore.AddSync(&buf), zap.NewAtomicLevel()))
+
+	c := config.Config{AppName: "app_name", Version: "1.0.0", Enrich: config.EnrichConfig{Enabled: true, Region: "asia-southeast1"}}
+	New(logger, c, level.Info, TypeApplication, "Order created").Write()
+
+	assert.Contains(t, buf.String(), `"version":"1.0.0","region":"asia-southeast1","host":{`)
+	assert.Contains(t, buf.String(), `"build":{`)
+
+	buf.Reset()
+	New(logger, config.Config{AppName: "app_name"}, level.Info, TypeApplication, "Order created").Write()
+	assert.NotContains(t, buf.String(), "host")
+}
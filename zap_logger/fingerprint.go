@@ -0,0 +1,65 @@
+package zap_logger
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+var (
+	quotedPattern = regexp.MustCompile("\"[^\"]*\"|'[^']*'|`[^`]*`")
+	uuidPattern   = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+	hexPattern    = regexp.MustCompile(`\b(0x[0-9a-fA-F]+|[0-9a-fA-F]{16,})\b`)
+	numberPattern = regexp.MustCompile(`\d+(\.\d+)?`)
+)
+
+// NormalizeMessage is the default fingerprint normalizer, it replaces quoted values, UUIDs,
+// long hex strings and numbers with "?" so messages embedding IDs share a fingerprint,
+// e.g. `order ORD_123 "abc" not found` becomes `order ORD_? ? not found`.
+func NormalizeMessage(msg string) string {
+	msg = quotedPattern.ReplaceAllString(msg, "?")
+	msg = uuidPattern.ReplaceAllString(msg, "?")
+	msg = hexPattern.ReplaceAllString(msg, "?")
+	return numberPattern.ReplaceAllString(msg, "?")
+}
+
+// fingerprint hashes the log type, the normalized message, the calling function,
+// the types of the error chain and the normalized message of its root cause.
+func fingerprint(t Type, msg string, caller string, err error, normalize func(string) string) string {
+	if normalize == nil {
+		normalize = NormalizeMessage
+	}
+
+	parts := []string{string(t), normalize(msg), caller}
+
+	if err != nil {
+		var types []string
+		root := err
+		for e := err; e != nil; e = errors.Unwrap(e) {
+			types = append(types, fmt.Sprintf("%T", e))
+			root = e
+		}
+		parts = append(parts, strings.Join(types, ">"), normalize(root.Error()))
+	}
+
+	sum := sha1.Sum([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// callerFunction returns the function name of the caller skip frames above its own caller.
+func callerFunction(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return ""
+	}
+
+	if fn := runtime.FuncForPC(pc); fn != nil {
+		return fn.Name()
+	}
+
+	return ""
+}
@@ -0,0 +1,80 @@
+package zap_logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/config"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"io/fs"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeMessage(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want string
+	}{
+		{msg: "order ORD_1234 not found", want: "order ORD_? not found"},
+		{msg: `user "alice" and 'bob' in ` + "`orders`", want: "user ? and ? in ?"},
+		{msg: "request 0af76519-16cd-43dd-8448-eb211c80319c failed", want: "request ? failed"},
+		{msg: "trace 0af7651916cd43dd8448eb211c80319c at 0x1f", want: "trace ? at ?"},
+		{msg: "took 1.25s after 3 retries", want: "took ?s after ? retries"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, NormalizeMessage(tt.msg))
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	base := fingerprint(TypeApplication, "order ORD_1 failed", "main.handle", fmt.Errorf("charge: %w", errors.New("card 4242 declined")), nil)
+
+	assert.Len(t, base, 16)
+	assert.Equal(t, base, fingerprint(TypeApplication, "order ORD_2 failed", "main.handle", fmt.Errorf("charge: %w", errors.New("card 1111 declined")), nil))
+
+	assert.NotEqual(t, base, fingerprint(TypeHandlerHTTP, "order ORD_1 failed", "main.handle", fmt.Errorf("charge: %w", errors.New("card 4242 declined")), nil))
+	assert.NotEqual(t, base, fingerprint(TypeApplication, "order ORD_1 failed", "main.other", fmt.Errorf("charge: %w", errors.New("card 4242 declined")), nil))
+	assert.NotEqual(t, base, fingerprint(TypeApplication, "order ORD_1 failed", "main.handle", fmt.Errorf("charge: %w", &fs.PathError{Op: "open", Path: "x", Err: fs.ErrNotExist}), nil))
+	assert.NotEqual(t, base, fingerprint(TypeApplication, "order ORD_1 failed", "main.handle", errors.New("card 4242 declined"), nil))
+
+	keepAll := func(s string) string { return s }
+	assert.NotEqual(t,
+		fingerprint(TypeApplication, "order ORD_1 failed", "main.handle", nil, keepAll),
+		fingerprint(TypeApplication, "order ORD_2 failed", "main.handle", nil, keepAll))
+}
+
+func TestBase_Write_Fingerprint(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(&buf), zap.NewAtomicLevelAt(zapcore.DebugLevel)))
+
+	write := func(cfg config.Config, l level.Level, msg string, err error) map[string]any {
+		buf.Reset()
+		New(logger, cfg, l, TypeApplication, msg).WithError(err).Write()
+
+		entry := map[string]any{}
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+		return entry["data"].(map[string]any)
+	}
+
+	assert.NotContains(t, write(config.Config{}, level.Info, "order 1 created", nil), "fingerprint")
+	assert.NotContains(t, write(config.Config{}, level.Warn, "order 1 slow", nil), "fingerprint")
+
+	first := write(config.Config{}, level.Error, "order 1 failed", nil)["fingerprint"]
+	assert.NotEmpty(t, first)
+	assert.Equal(t, first, write(config.Config{}, level.Error, "order 2 failed", nil)["fingerprint"])
+
+	withErr := write(config.Config{}, level.Info, "order 1 retried", errors.New("timeout"))["fingerprint"]
+	assert.NotEmpty(t, withErr)
+	assert.NotEqual(t, first, withErr)
+
+	upper := config.Config{FingerprintNormalizer: strings.ToUpper}
+	assert.NotEqual(t,
+		write(upper, level.Error, "order 1 failed", nil)["fingerprint"],
+		write(upper, level.Error, "order 2 failed", nil)["fingerprint"])
+}
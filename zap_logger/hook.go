@@ -0,0 +1,108 @@
+package zap_logger
+
+import (
+	"fmt"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/log"
+	"sync"
+)
+
+// Entry is the read/write view of a log entry passed to hooks.
+type Entry struct {
+	ID        string // ID is written as "log_id" when log IDs are enabled, see config.Config.LogID.
+	Type      Type
+	Level     level.Level
+	Message   string
+	Alert     bool
+	AlertInfo *log.AlertInfo
+	Data      map[string]any // Data holds the payloads, error and tracing written under "data".
+	AppFields map[string]any // AppFields holds the app data written under data[AppName].
+	Err       error          // Err is the error given to WithError, changing it only affects the fingerprint.
+
+	extra []*Entry
+}
+
+// Clone returns a copy of the entry with its own Data and AppFields maps, e.g. to fan it out with Also.
+// The copy gets a new ID when it is written.
+func (e *Entry) Clone() *Entry {
+	c := *e
+	c.ID = ""
+	c.extra = nil
+	c.Data = make(map[string]any, len(e.Data))
+	for k, v := range e.Data {
+		c.Data[k] = v
+	}
+	c.AppFields = make(map[string]any, len(e.AppFields))
+	for k, v := range e.AppFields {
+		c.AppFields[k] = v
+	}
+	return &c
+}
+
+// Also writes another entry after this one, it runs through the hooks registered after the current hook only.
+func (e *Entry) Also(other *Entry) {
+	e.extra = append(e.extra, other)
+}
+
+// Hook is called for every entry before it is written, it may modify the entry and returns false to drop it.
+type Hook func(e *Entry) bool
+
+// Hooks is an ordered chain of hooks safe for concurrent use.
+// Hooks run in the order they were added, a panicking hook is skipped and reported in data "hook_error".
+type Hooks struct {
+	mu    sync.RWMutex
+	hooks []Hook
+}
+
+// Add appends a hook to the end of the chain.
+func (h *Hooks) Add(hook Hook) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	hooks := make([]Hook, len(h.hooks), len(h.hooks)+1)
+	copy(hooks, h.hooks)
+	h.hooks = append(hooks, hook)
+}
+
+// Run passes the entry through the chain and returns the entries to write in order.
+func (h *Hooks) Run(e *Entry) []*Entry {
+	h.mu.RLock()
+	hooks := h.hooks
+	h.mu.RUnlock()
+
+	return run(hooks, 0, e)
+}
+
+func run(hooks []Hook, from int, e *Entry) []*Entry {
+	var extras []*Entry
+
+	for i := from; i < len(hooks); i++ {
+		keep := callHook(hooks[i], i, e)
+
+		for _, x := range e.extra {
+			extras = append(extras, run(hooks, i+1, x)...)
+		}
+		e.extra = nil
+
+		if !keep {
+			return extras
+		}
+	}
+
+	return append([]*Entry{e}, extras...)
+}
+
+// callHook runs a hook and isolates its panic, the entry is kept as the hook left it.
+func callHook(hook Hook, i int, e *Entry) (keep bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e.Data == nil {
+				e.Data = map[string]any{}
+			}
+			e.Data["hook_error"] = fmt.Sprintf("hook %d panicked: %v", i, r)
+			keep = true
+		}
+	}()
+
+	return hook(e)
+}
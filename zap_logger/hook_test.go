@@ -0,0 +1,88 @@
+package zap_logger
+
+import (
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func messages(entries []*Entry) []string {
+	var out []string
+	for _, e := range entries {
+		out = append(out, e.Message)
+	}
+	return out
+}
+
+func TestHooks_Order(t *testing.T) {
+	h := &Hooks{}
+	h.Add(func(e *Entry) bool {
+		e.Message += " a"
+		return true
+	})
+	h.Add(func(e *Entry) bool {
+		e.Message += " b"
+		return true
+	})
+
+	assert.Equal(t, []string{"m a b"}, messages(h.Run(&Entry{Message: "m"})))
+}
+
+func TestHooks_Drop(t *testing.T) {
+	h := &Hooks{}
+	called := false
+	h.Add(func(e *Entry) bool {
+		return e.Message != "health check"
+	})
+	h.Add(func(e *Entry) bool {
+		called = true
+		return true
+	})
+
+	assert.Empty(t, h.Run(&Entry{Message: "health check"}))
+	assert.False(t, called)
+	assert.Len(t, h.Run(&Entry{Message: "order created"}), 1)
+}
+
+func TestHooks_FanOut(t *testing.T) {
+	h := &Hooks{}
+	h.Add(func(e *Entry) bool {
+		e.Message += " 1"
+		return true
+	})
+	h.Add(func(e *Entry) bool {
+		if !e.Level.Less(level.Error) {
+			copied := e.Clone()
+			copied.Message = "forwarded"
+			copied.Data["forwarded"] = true
+			e.Also(copied)
+		}
+		return e.Message != "drop 1"
+	})
+	h.Add(func(e *Entry) bool {
+		e.Message += " 3"
+		return true
+	})
+
+	entries := h.Run(&Entry{Message: "failed", Level: level.Error, Data: map[string]any{"order": 1}})
+	assert.Equal(t, []string{"failed 1 3", "forwarded 3"}, messages(entries))
+	assert.Equal(t, map[string]any{"order": 1}, entries[0].Data)
+	assert.Equal(t, map[string]any{"order": 1, "forwarded": true}, entries[1].Data)
+
+	assert.Equal(t, []string{"forwarded 3"}, messages(h.Run(&Entry{Message: "drop", Level: level.Error})))
+}
+
+func TestHooks_Panic(t *testing.T) {
+	h := &Hooks{}
+	h.Add(func(e *Entry) bool {
+		panic("boom")
+	})
+	h.Add(func(e *Entry) bool {
+		e.Data["tenant"] = "t1"
+		return true
+	})
+
+	entries := h.Run(&Entry{Message: "m"})
+	assert.Len(t, entries, 1)
+	assert.Equal(t, map[string]any{"hook_error": "hook 0 panicked: boom", "tenant": "t1"}, entries[0].Data)
+}
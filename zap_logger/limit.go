@@ -0,0 +1,171 @@
+package zap_logger
+
+import (
+	"container/list"
+	"fmt"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/log"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"runtime"
+	"sync"
+	"time"
+)
+
+type limitMode int
+
+const (
+	limitNone limitMode = iota
+	limitOnce
+	limitEveryN
+	limitRate
+)
+
+// limit holds the Once, EveryN or RateLimit option of an entry, keyed by call site unless a custom key is set.
+type limit struct {
+	key    string
+	custom bool
+	mode   limitMode
+	n      int
+	per    time.Duration
+}
+
+// limitState counts the entries of one key, it is shared by every entry with that key.
+type limitState struct {
+	key string
+
+	mu          sync.Mutex
+	count       int
+	suppressed  int
+	windowStart time.Time
+	windowCount int
+}
+
+// maxLimitStates caps the keys counted at once, e.g. LimitKey with a user ID would otherwise grow without bound.
+// The least recently used key is dropped first and counts from zero when it is used again.
+const maxLimitStates = 10000
+
+var (
+	limitStates = newLimitStore(maxLimitStates)
+	limitNow    = time.Now
+)
+
+// limitStore holds the limitState of every key, in least recently used order.
+type limitStore struct {
+	mu    sync.Mutex
+	max   int
+	keys  map[string]*list.Element
+	order *list.List // order holds *limitState, the most recently used first.
+}
+
+func newLimitStore(max int) *limitStore {
+	return &limitStore{max: max, keys: map[string]*list.Element{}, order: list.New()}
+}
+
+// get returns the state of key, it creates it and drops the least recently used state over max.
+func (s *limitStore) get(key string) *limitState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.keys[key]; ok {
+		s.order.MoveToFront(e)
+		return e.Value.(*limitState)
+	}
+
+	state := &limitState{key: key}
+	s.keys[key] = s.order.PushFront(state)
+
+	if s.order.Len() > s.max {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.keys, oldest.Value.(*limitState).key)
+	}
+
+	return state
+}
+
+// withLimit returns a copy of l with the limit updated by set, the key defaults to the call site skip frames above.
+func (l Logger) withLimit(skip int, set func(*limit)) *Logger {
+	next := limit{}
+	if l.limit != nil {
+		next = *l.limit
+	}
+
+	if !next.custom {
+		next.key = callSite(skip + 1)
+	}
+	set(&next)
+
+	l.limit = &next
+	return &l
+}
+
+// allow reports whether the entry should be written and how many entries were suppressed since the last one written.
+func (lim *limit) allow() (bool, int) {
+	if lim.mode == limitNone {
+		return true, 0
+	}
+
+	s := limitStates.get(lim.key)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := lim.n
+	if n <= 0 {
+		n = 1
+	}
+
+	var ok bool
+	switch lim.mode {
+	case limitOnce:
+		ok = s.count == 0
+		s.count++
+	case limitEveryN:
+		ok = s.count%n == 0
+		s.count++
+	case limitRate:
+		now := limitNow()
+		if now.Sub(s.windowStart) >= lim.per {
+			s.windowStart = now
+			s.windowCount = 0
+		}
+		ok = s.windowCount < n
+		if ok {
+			s.windowCount++
+		}
+	}
+
+	if !ok {
+		s.suppressed++
+		return false, 0
+	}
+
+	suppressed := s.suppressed
+	s.suppressed = 0
+	return true, suppressed
+}
+
+// levelEnabler is implemented by outputs that can tell whether they write a level, e.g. under a reloadable config.
+type levelEnabler interface {
+	Enabled(lvl zapcore.Level) bool
+}
+
+// enabled reports whether out writes entries of lvl, outputs that cannot tell are assumed to.
+func enabled(out log.ZapLogger, lvl zapcore.Level) bool {
+	switch t := out.(type) {
+	case *zap.Logger:
+		return t.Core().Enabled(lvl)
+	case levelEnabler:
+		return t.Enabled(lvl)
+	default:
+		return true
+	}
+}
+
+func callSite(skip int) string {
+	_, file, line, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return "unknown"
+	}
+	return fmt.Sprintf("%s:%d", file, line)
+}
@@ -0,0 +1,169 @@
+package zap_logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/config"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/log"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newLimitTestLogger(t *testing.T) (func(msg string) *Logger, func() []map[string]any) {
+	t.Cleanup(func() {
+		limitStates = newLimitStore(maxLimitStates)
+		limitNow = time.Now
+	})
+
+	var buf bytes.Buffer
+	logger := zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(&buf), zap.NewAtomicLevelAt(zapcore.DebugLevel)))
+
+	newEntry := func(msg string) *Logger {
+		return New(logger, config.Config{}, level.Info, TypeApplication, msg)
+	}
+
+	lines := func() []map[string]any {
+		var out []map[string]any
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			if line == "" {
+				continue
+			}
+			entry := map[string]any{}
+			assert.NoError(t, json.Unmarshal([]byte(line), &entry))
+			out = append(out, entry)
+		}
+		buf.Reset()
+		return out
+	}
+
+	return newEntry, lines
+}
+
+func TestBase_Once(t *testing.T) {
+	newEntry, lines := newLimitTestLogger(t)
+
+	for i := 0; i < 3; i++ {
+		newEntry("first site").Once().Write()
+		newEntry("second site").Once().Write()
+	}
+
+	got := lines()
+	assert.Len(t, got, 2)
+	assert.Equal(t, "first site", got[0]["msg"])
+	assert.Equal(t, "second site", got[1]["msg"])
+}
+
+func TestBase_EveryN(t *testing.T) {
+	newEntry, lines := newLimitTestLogger(t)
+
+	for i := 0; i < 7; i++ {
+		newEntry("retry").EveryN(3).Write()
+	}
+
+	got := lines()
+	assert.Len(t, got, 3)
+	assert.Equal(t, map[string]any{}, got[0]["data"])
+	assert.Equal(t, map[string]any{"suppressed": float64(2)}, got[1]["data"])
+	assert.Equal(t, map[string]any{"suppressed": float64(2)}, got[2]["data"])
+}
+
+func TestBase_RateLimit(t *testing.T) {
+	newEntry, lines := newLimitTestLogger(t)
+
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	limitNow = func() time.Time { return now }
+
+	write := func() {
+		newEntry("poison message").RateLimit(2, time.Second).Write()
+	}
+
+	for i := 0; i < 5; i++ {
+		write()
+	}
+	assert.Len(t, lines(), 2)
+
+	now = now.Add(time.Second)
+	write()
+	write()
+	write()
+
+	got := lines()
+	assert.Len(t, got, 2)
+	assert.Equal(t, map[string]any{"suppressed": float64(3)}, got[0]["data"])
+	assert.Equal(t, map[string]any{}, got[1]["data"])
+}
+
+func TestBase_RateLimit_NoInterval(t *testing.T) {
+	newEntry, lines := newLimitTestLogger(t)
+
+	for i := 0; i < 5; i++ {
+		newEntry("no interval").RateLimit(2, 0).Write()
+	}
+
+	assert.Len(t, lines(), 3)
+}
+
+func TestBase_Limit_Disabled(t *testing.T) {
+	t.Cleanup(func() { limitStates = newLimitStore(maxLimitStates) })
+
+	var buf bytes.Buffer
+	lvl := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	logger := zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(&buf), lvl))
+
+	write := func() {
+		New(logger, config.Config{LogLevel: level.Debug}, level.Debug, TypeApplication, "cache miss").Once().Write()
+	}
+
+	// Debug entries dropped by the zap level do not use up Once.
+	write()
+	assert.Empty(t, buf.String())
+
+	lvl.SetLevel(zapcore.DebugLevel)
+	write()
+	write()
+	assert.Equal(t, 1, strings.Count(buf.String(), "cache miss"))
+}
+
+func TestLimitStore_Evicts(t *testing.T) {
+	s := newLimitStore(2)
+
+	a := s.get("a")
+	s.get("b")
+	s.get("a")
+
+	// b is the least recently used key when c is added.
+	s.get("c")
+	assert.Same(t, a, s.get("a"))
+	assert.NotContains(t, s.keys, "b")
+	assert.Equal(t, 2, s.order.Len())
+}
+
+func TestBase_LimitKey(t *testing.T) {
+	newEntry, lines := newLimitTestLogger(t)
+
+	for _, partition := range []string{"p0", "p1", "p0", "p1"} {
+		newEntry("consumer lagging").LimitKey("lag:" + partition).Once().Write()
+	}
+	newEntry("other site, same key").Once().LimitKey("lag:p0").Write()
+
+	assert.Len(t, lines(), 2)
+}
+
+func TestBase_Limit_DoesNotShareState(t *testing.T) {
+	newEntry, lines := newLimitTestLogger(t)
+
+	base := newEntry("base")
+	var once log.Log = base.Once()
+
+	once.Write()
+	once.Write()
+	base.Write()
+	base.Write()
+
+	assert.Len(t, lines(), 3)
+}
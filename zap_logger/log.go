@@ -8,6 +8,7 @@ import (
 	"github.com/Sellsuki/sellsuki-go-logger/v2/log"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"time"
 )
 
 type Type string
@@ -18,6 +19,9 @@ const (
 	TypeApplication  Type = "application"
 	TypeHandlerKafka Type = "handler.kafka"
 	TypeHandlerHTTP  Type = "handler.http"
+	TypeClientHTTP   Type = "client.http"
+	TypeDBQuery      Type = "db.query"
+	TypeHandlerJob   Type = "handler.job"
 )
 
 type Logger struct {
@@ -27,25 +31,117 @@ type Logger struct {
 	Type      Type
 	Level     level.Level
 	Alert     bool
+	AlertInfo *log.AlertInfo
 	Message   string
 	Data      map[string]any
 	AppFields map[string]any
+	Err       error
+	Hooks     *Hooks
+
+	limit      *limit
+	callerSkip int
 }
 
 func (l Logger) Write() {
-	if len(l.AppFields) > 0 {
-		l.Data[l.config.AppName] = l.AppFields
+	// The zap core filters by LogLevel, except for per type levels and levels sharing a zap level such as Trace and Debug.
+	if min := l.config.LevelFor(string(l.Type)); l.Level.Less(min) && (len(l.config.TypeLevels) > 0 || level.ToZap(l.Level) == level.ToZap(min)) {
+		return
+	}
+
+	if l.limit != nil {
+		// Entries the output drops anyway must not use up the limit, e.g. debug entries while the level is info.
+		if !enabled(l.logger, level.ToZap(l.Level)) {
+			return
+		}
+		ok, suppressed := l.limit.allow()
+		if !ok {
+			return
+		}
+		if suppressed > 0 {
+			l.Data["suppressed"] = suppressed
+		}
+	}
+
+	skip, caller := resolveCaller(1, l.config.CallerSkip+l.callerSkip)
+	out := withCallerSkip(l.logger, skip)
+
+	e := &Entry{
+		ID:        l.config.NewLogID(),
+		Type:      l.Type,
+		Level:     l.Level,
+		Message:   l.Message,
+		Alert:     l.Alert,
+		AlertInfo: l.AlertInfo,
+		Data:      l.Data,
+		AppFields: l.AppFields,
+		Err:       l.Err,
+	}
+
+	entries := []*Entry{e}
+	if l.Hooks != nil {
+		entries = l.Hooks.Run(e)
+	}
+
+	// Log is called from Write itself so the zap caller skip keeps pointing at the caller of Write.
+	for _, e := range entries {
+		if e.ID == "" {
+			e.ID = l.config.NewLogID()
+		}
+		out.Log(level.ToZap(e.Level), e.Message, l.fields(e, caller)...)
+	}
+}
+
+// fields builds the envelope fields of an entry.
+func (l Logger) fields(e *Entry, caller string) []zap.Field {
+	if e.Data == nil {
+		e.Data = map[string]any{}
+	}
+
+	if len(e.AppFields) > 0 {
+		e.Data[l.config.AppName] = e.AppFields
+	}
+
+	if _, hasError := e.Data["error"]; !e.Level.Less(level.Error) || e.Err != nil || hasError {
+		e.Data["fingerprint"] = fingerprint(e.Type, e.Message, caller, e.Err, l.config.FingerprintNormalizer)
+	}
+
+	data := e.Data
+	if len(l.config.Redact) > 0 {
+		data = redactData(e.Data, l.config.Redact)
+	}
+
+	f := make([]zap.Field, 0, 12)
+	if e.ID != "" {
+		f = append(f, zap.String("log_id", e.ID))
 	}
 
-	f := []zap.Field{
+	f = append(f,
 		zap.String("app_name", l.config.AppName),
 		zap.String("version", l.config.Version),
-		zap.Int("alert", BoolToInt[l.Alert]),
-		zap.String("log_type", string(l.Type)),
-		zap.Any("data", l.Data),
+	)
+
+	if l.config.Enrich.Enabled {
+		processOnce.Do(func() { process = readProcessMetadata() })
+		f = append(f, enrichFields(l.config.Enrich, process)...)
 	}
 
-	l.logger.Log(level.ToZap(l.Level), l.Message, f...)
+	f = append(f, zap.Int("alert", BoolToInt[e.Alert]))
+
+	// Trace and Notice share a zap level with Debug and Info, sinks such as the OTLP exporter read them from the field.
+	if level.FromZap(level.ToZap(e.Level)) != e.Level {
+		f = append(f, level.Field(e.Level))
+	}
+
+	if e.Alert && e.AlertInfo != nil {
+		f = append(f, zap.Any("alert_info", e.AlertInfo))
+	}
+
+	f = append(f,
+		zap.String("log_type", string(e.Type)),
+		zap.Any("data", data),
+	)
+
+	return f
 }
 
 func (l Logger) SetMessage(msg string) log.Log {
@@ -58,8 +154,16 @@ func (l Logger) SetLevel(level level.Level) log.Log {
 	return &l
 }
 
-func (l Logger) SetAlert(bool bool) log.Log {
+// SetAlert keeps writing "alert" as 0 or 1, the optional info is written as "alert_info" on alert entries only.
+func (l Logger) SetAlert(bool bool, info ...log.AlertInfo) log.Log {
 	l.Alert = bool
+	l.AlertInfo = nil
+
+	if bool && len(info) > 0 {
+		i := info[0]
+		l.AlertInfo = &i
+	}
+
 	return &l
 }
 
@@ -85,6 +189,8 @@ func (l Logger) WithError(err error) log.Log {
 		return &l
 	}
 
+	l.Err = err
+
 	return l.WithField("error", err.Error())
 }
 
@@ -111,11 +217,57 @@ func (l Logger) WithFields(fields map[string]any) log.Log {
 	return &l
 }
 
+// Once writes the entry only the first time its call site, or the key set with LimitKey, is reached.
+func (l Logger) Once() log.Log {
+	return l.withLimit(1, func(lim *limit) {
+		lim.mode = limitOnce
+	})
+}
+
+// EveryN writes the first entry of its call site or key and then one every n,
+// the written entries carry the number of entries skipped in between as "suppressed".
+func (l Logger) EveryN(n int) log.Log {
+	return l.withLimit(1, func(lim *limit) {
+		lim.mode = limitEveryN
+		lim.n = n
+	})
+}
+
+// RateLimit writes at most n entries of its call site or key per interval,
+// the first entry written after entries were dropped carries their number as "suppressed".
+// An interval of 0 or less has no window to count in, the entries are limited like EveryN(n).
+func (l Logger) RateLimit(n int, per time.Duration) log.Log {
+	return l.withLimit(1, func(lim *limit) {
+		lim.mode = limitRate
+		if per <= 0 {
+			lim.mode = limitEveryN
+		}
+		lim.n = n
+		lim.per = per
+	})
+}
+
+// LimitKey makes Once, EveryN and RateLimit count entries by key instead of by call site,
+// e.g. to share a limit between call sites or to limit per Kafka partition.
+func (l Logger) LimitKey(key string) log.Log {
+	return l.withLimit(1, func(lim *limit) {
+		lim.key = key
+		lim.custom = true
+	})
+}
+
+// CallerSkip reports the caller n more frames up the stack, e.g. from a function wrapping the logger.
+// Functions marked with MarkHelper are skipped without it.
+func (l Logger) CallerSkip(n int) log.Log {
+	l.callerSkip += n
+	return &l
+}
+
 func (l Logger) WithStackTrace() log.Log {
 	return l.WithField("stack_trace", CaptureStackTrace(2))
 }
 
-func New(logger *zap.Logger, cfg config.Config, l level.Level, t Type, msg string) *Logger {
+func New(logger log.ZapLogger, cfg config.Config, l level.Level, t Type, msg string) *Logger {
 	return &Logger{
 		logger:    logger,
 		config:    cfg,
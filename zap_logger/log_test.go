@@ -11,7 +11,9 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestBase_SetAlert(t *testing.T) {
@@ -23,6 +25,7 @@ func TestBase_SetAlert(t *testing.T) {
 	}
 	type args struct {
 		bool bool
+		info []log.AlertInfo
 	}
 	tests := []struct {
 		name   string
@@ -53,6 +56,29 @@ func TestBase_SetAlert(t *testing.T) {
 				Message: "",
 			},
 		},
+		{
+			name:   "Set alert with info",
+			fields: fields{},
+			args:   args{bool: true, info: []log.AlertInfo{{Severity: log.AlertSeverityP1, Team: "payments"}}},
+			want: &Logger{
+				config:    config.Config{},
+				Level:     level.Level(0),
+				Alert:     true,
+				AlertInfo: &log.AlertInfo{Severity: log.AlertSeverityP1, Team: "payments"},
+				Message:   "",
+			},
+		},
+		{
+			name:   "Set alert to false with info",
+			fields: fields{},
+			args:   args{bool: false, info: []log.AlertInfo{{Severity: log.AlertSeverityP1}}},
+			want: &Logger{
+				config:  config.Config{},
+				Level:   level.Level(0),
+				Alert:   false,
+				Message: "",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -62,7 +88,7 @@ func TestBase_SetAlert(t *testing.T) {
 				Alert:   tt.fields.Alert,
 				Message: tt.fields.Message,
 			}
-			if got := l.SetAlert(tt.args.bool); !reflect.DeepEqual(got, tt.want) {
+			if got := l.SetAlert(tt.args.bool, tt.args.info...); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("SetAlert() = %v, want %v", got, tt.want)
 			}
 		})
@@ -296,6 +322,7 @@ func TestBase_WithAppData(t *testing.T) {
 
 func TestBase_WithError(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
+	sampleErr := fmt.Errorf("Sample error message")
 
 	type fields struct {
 		logger    *zap.Logger
@@ -322,11 +349,12 @@ func TestBase_WithError(t *testing.T) {
 				Fields: map[string]any{},
 			},
 			args: args{
-				err: fmt.Errorf("Sample error message"),
+				err: sampleErr,
 			},
 			want: &Logger{
 				logger: logger,
 				Data:   map[string]any{"error": "Sample error message"},
+				Err:    sampleErr,
 			},
 		},
 		{
@@ -657,6 +685,112 @@ func TestBase_Write(t *testing.T) {
 	assert.Equal(t, expectedLog, logOutput)
 }
 
+func TestBase_Write_AlertInfo(t *testing.T) {
+	var buf bytes.Buffer
+
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.EncodeTime = FixedTimeEncoder
+	logger := zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(encoderConfig), zapcore.AddSync(&buf), zap.NewAtomicLevel()))
+
+	New(logger, config.Config{AppName: "app_name", Version: "1.0.0"}, level.Warn, TypeApplication, "Payment failed").
+		SetAlert(true, log.AlertInfo{Severity: log.AlertSeverityCritical, Team: "payments", RunbookURL: "https://runbook/payments"}).
+		Write()
+
+	expectedLog := "{\"level\":\"warn\",\"ts\":\"fixed\",\"msg\":\"Payment failed\",\"app_name\":\"app_name\",\"version\":\"1.0.0\",\"alert\":1,\"alert_info\":{\"severity\":\"critical\",\"team\":\"payments\",\"runbook_url\":\"https://runbook/payments\"},\"log_type\":\"application\",\"data\":{}}\n"
+
+	assert.Equal(t, expectedLog, buf.String())
+}
+
+func TestBase_Write_TypeLevels(t *testing.T) {
+	m := &MockLogger{}
+	c := config.Config{AppName: "app_name", LogLevel: level.Info, TypeLevels: map[string]level.Level{"db.query": level.Warn}}
+
+	New(m, c, level.Info, TypeDBQuery, "query").Write()
+	assert.False(t, m.logged, "below the level of its type")
+
+	New(m, c, level.Warn, TypeDBQuery, "slow query").Write()
+	assert.True(t, m.logged)
+
+	m.logged = false
+	New(m, c, level.Debug, TypeApplication, "debug").Write()
+	assert.False(t, m.logged, "below LogLevel for types without their own level")
+}
+
+func TestBase_Write_ExtraLevels(t *testing.T) {
+	m := &MockLogger{}
+
+	New(m, config.Config{LogLevel: level.Debug}, level.Trace, TypeApplication, "trace").Write()
+	assert.False(t, m.logged, "trace shares the zap debug level")
+
+	New(m, config.Config{LogLevel: level.Trace}, level.Trace, TypeApplication, "trace").Write()
+	assert.True(t, m.logged)
+	assert.Equal(t, zapcore.DebugLevel, m.level)
+
+	m.logged = false
+	New(m, config.Config{LogLevel: level.Notice}, level.Info, TypeApplication, "info").Write()
+	assert.False(t, m.logged, "info shares the zap info level")
+
+	New(m, config.Config{LogLevel: level.Notice}, level.Notice, TypeApplication, "notice").Write()
+	assert.True(t, m.logged)
+	assert.Equal(t, zapcore.InfoLevel, m.level)
+}
+
+func TestBase_Write_Redact(t *testing.T) {
+	var buf bytes.Buffer
+
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.EncodeTime = FixedTimeEncoder
+	logger := zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(encoderConfig), zapcore.AddSync(&buf), zap.NewAtomicLevel()))
+
+	New(logger, config.Config{AppName: "app_name", Version: "1.0.0", Redact: []string{"password", "Authorization"}}, level.Info, TypeHandlerHTTP, "Login").
+		WithField("http_request", log.HTTPRequestPayload{Method: "POST", Headers: map[string]string{"authorization": "Bearer t"}}).(*Logger).
+		WithAppData("password", "hunter2").
+		Write()
+
+	assert.Contains(t, buf.String(), `"app_name":{"password":"[REDACTED]"}`)
+	assert.Contains(t, buf.String(), `"headers":{"authorization":"[REDACTED]"}`)
+	assert.NotContains(t, buf.String(), "hunter2")
+	assert.NotContains(t, buf.String(), "Bearer")
+}
+
+func TestBase_Write_LogID(t *testing.T) {
+	var buf bytes.Buffer
+
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.EncodeTime = FixedTimeEncoder
+	logger := zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(encoderConfig), zapcore.AddSync(&buf), zap.NewAtomicLevel()))
+
+	n := 0
+	c := config.Config{AppName: "app_name", Version: "1.0.0", LogIDGenerator: func(time.Time) string {
+		n++
+		return fmt.Sprintf("id-%d", n)
+	}}
+
+	var seen []string
+	hooks := &Hooks{}
+	hooks.Add(func(e *Entry) bool {
+		seen = append(seen, e.ID)
+		if e.Message == "Order failed" {
+			e.Also(e.Clone())
+		}
+		return true
+	})
+
+	l := New(logger, c, level.Error, TypeApplication, "Order failed")
+	l.Hooks = hooks
+	l.Write()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], `{"level":"error","ts":"fixed","msg":"Order failed","log_id":"id-1","app_name":"app_name"`), lines[0])
+	assert.Contains(t, lines[1], `"log_id":"id-2"`, "a cloned entry gets its own ID")
+	assert.Equal(t, []string{"id-1"}, seen)
+
+	buf.Reset()
+	New(logger, config.Config{AppName: "app_name"}, level.Info, TypeApplication, "Order created").Write()
+	assert.NotContains(t, buf.String(), "log_id")
+}
+
 func TestBase_New(t *testing.T) {
 	// Create a zap.Logger for testing purposes
 	logger, _ := zap.NewDevelopment()
@@ -0,0 +1,60 @@
+package zap_logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// RedactedValue replaces the values of redacted keys.
+const RedactedValue = "[REDACTED]"
+
+// Redact replaces the value of every object key listed in keys (case-insensitive, at any depth)
+// of a decoded JSON value with RedactedValue. Maps and slices are modified in place.
+func Redact(v any, keys []string) any {
+	set := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		set[strings.ToLower(k)] = struct{}{}
+	}
+
+	return redactValue(v, set)
+}
+
+func redactValue(v any, keys map[string]struct{}) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, val := range t {
+			if _, ok := keys[strings.ToLower(k)]; ok {
+				t[k] = RedactedValue
+				continue
+			}
+			t[k] = redactValue(val, keys)
+		}
+	case []any:
+		for i, val := range t {
+			t[i] = redactValue(val, keys)
+		}
+	}
+
+	return v
+}
+
+// redactData returns a redacted copy of the data of an entry, payload structs are converted through JSON
+// so their keys are redacted too. The data is returned untouched when it cannot be encoded.
+func redactData(data map[string]any, keys []string) map[string]any {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return data
+	}
+
+	out := map[string]any{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&out); err != nil {
+		return data
+	}
+
+	Redact(out, keys)
+
+	return out
+}
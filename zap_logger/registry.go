@@ -0,0 +1,123 @@
+package zap_logger
+
+import (
+	"fmt"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"regexp"
+	"sort"
+	"sync"
+)
+
+// TypeDefinition describes a custom log type registered with RegisterType.
+type TypeDefinition struct {
+	Name         Type            // Name is the value written to log_type, e.g. "handler.grpc".
+	PayloadKey   string          // PayloadKey is the key under data that holds the payload, e.g. "grpc".
+	DefaultLevel level.Level     // DefaultLevel is the level of new entries of this type.
+	Validate     func(any) error // Validate optionally checks the payload before it is attached to an entry.
+	Payload      any             // Payload is an optional zero value of the payload struct, used to generate the JSON schema.
+}
+
+var (
+	typeNamePattern = regexp.MustCompile(`^[a-z0-9_]+(\.[a-z0-9_]+)*$`)
+
+	builtinTypes = map[Type]struct{}{
+		TypeAudit:        {},
+		TypeEvent:        {},
+		TypeApplication:  {},
+		TypeHandlerKafka: {},
+		TypeHandlerHTTP:  {},
+		TypeClientHTTP:   {},
+		TypeDBQuery:      {},
+		TypeHandlerJob:   {},
+	}
+
+	// reservedPayloadKeys are data keys written by the Logger itself.
+	reservedPayloadKeys = map[string]struct{}{
+		"error":        {},
+		"tracing":      {},
+		"stack_trace":  {},
+		"schema_error": {},
+		"fingerprint":  {},
+		"suppressed":   {},
+		"hook_error":   {},
+	}
+
+	registryMu sync.RWMutex
+	registry   = map[Type]TypeDefinition{}
+)
+
+// RegisterType registers a custom log type so entries of that type share the envelope of the built-in ones.
+// It is meant to be called during initialization and fails on invalid or duplicate definitions.
+func RegisterType(def TypeDefinition) error {
+	if !typeNamePattern.MatchString(string(def.Name)) {
+		return fmt.Errorf("invalid log type name %q", def.Name)
+	}
+
+	if def.PayloadKey == "" {
+		return fmt.Errorf("log type %q: payload key is required", def.Name)
+	}
+
+	if _, ok := reservedPayloadKeys[def.PayloadKey]; ok {
+		return fmt.Errorf("log type %q: payload key %q is reserved", def.Name, def.PayloadKey)
+	}
+
+	if _, ok := builtinTypes[def.Name]; ok {
+		return fmt.Errorf("log type %q is built-in", def.Name)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := registry[def.Name]; ok {
+		return fmt.Errorf("log type %q is already registered", def.Name)
+	}
+
+	registry[def.Name] = def
+
+	return nil
+}
+
+// MustRegisterType is like RegisterType but panics on error, it returns the registered type for use in var blocks.
+func MustRegisterType(def TypeDefinition) Type {
+	if err := RegisterType(def); err != nil {
+		panic(err)
+	}
+
+	return def.Name
+}
+
+// UnregisterType removes a type registered with RegisterType, e.g. in the cleanup of a test registering it.
+// Entries of the type written afterwards carry a schema_error like any unregistered type.
+func UnregisterType(t Type) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	delete(registry, t)
+}
+
+// LookupType returns the definition of a custom log type.
+func LookupType(t Type) (TypeDefinition, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	def, ok := registry[t]
+	return def, ok
+}
+
+// Types returns the built-in and registered log types sorted by name.
+func Types() []Type {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	types := make([]Type, 0, len(builtinTypes)+len(registry))
+	for t := range builtinTypes {
+		types = append(types, t)
+	}
+	for t := range registry {
+		types = append(types, t)
+	}
+
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+
+	return types
+}
@@ -0,0 +1,95 @@
+package zap_logger
+
+import (
+	"errors"
+	"github.com/Sellsuki/sellsuki-go-logger/v2/level"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRegisterType(t *testing.T) {
+	t.Cleanup(func() { UnregisterType("handler.grpc") })
+
+	tests := []struct {
+		name    string
+		def     TypeDefinition
+		wantErr string
+	}{
+		{
+			name: "Register a valid type",
+			def:  TypeDefinition{Name: "handler.grpc", PayloadKey: "grpc", DefaultLevel: level.Info},
+		},
+		{
+			name:    "Register the same type twice",
+			def:     TypeDefinition{Name: "handler.grpc", PayloadKey: "grpc"},
+			wantErr: `log type "handler.grpc" is already registered`,
+		},
+		{
+			name:    "Register a built-in type",
+			def:     TypeDefinition{Name: TypeAudit, PayloadKey: "audit"},
+			wantErr: `log type "audit" is built-in`,
+		},
+		{
+			name:    "Register an invalid name",
+			def:     TypeDefinition{Name: "Handler GRPC", PayloadKey: "grpc"},
+			wantErr: `invalid log type name "Handler GRPC"`,
+		},
+		{
+			name:    "Register without payload key",
+			def:     TypeDefinition{Name: "handler.amqp"},
+			wantErr: `log type "handler.amqp": payload key is required`,
+		},
+		{
+			name:    "Register a reserved payload key",
+			def:     TypeDefinition{Name: "handler.amqp", PayloadKey: "error"},
+			wantErr: `log type "handler.amqp": payload key "error" is reserved`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RegisterType(tt.def)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestMustRegisterType(t *testing.T) {
+	validate := func(any) error { return errors.New("invalid") }
+	t.Cleanup(func() { UnregisterType("handler.websocket") })
+
+	typ := MustRegisterType(TypeDefinition{Name: "handler.websocket", PayloadKey: "websocket", DefaultLevel: level.Debug, Validate: validate})
+	assert.Equal(t, Type("handler.websocket"), typ)
+
+	def, ok := LookupType(typ)
+	assert.True(t, ok)
+	assert.Equal(t, "websocket", def.PayloadKey)
+	assert.Equal(t, level.Debug, def.DefaultLevel)
+	assert.EqualError(t, def.Validate(nil), "invalid")
+
+	assert.Panics(t, func() {
+		MustRegisterType(TypeDefinition{Name: "handler.websocket", PayloadKey: "websocket"})
+	})
+
+	_, ok = LookupType("handler.unknown")
+	assert.False(t, ok)
+
+	UnregisterType(typ)
+	_, ok = LookupType(typ)
+	assert.False(t, ok)
+}
+
+func TestTypes(t *testing.T) {
+	MustRegisterType(TypeDefinition{Name: "handler.amqp_types", PayloadKey: "amqp"})
+	t.Cleanup(func() { UnregisterType("handler.amqp_types") })
+
+	types := Types()
+	assert.Contains(t, types, TypeApplication)
+	assert.Contains(t, types, TypeHandlerJob)
+	assert.Contains(t, types, Type("handler.amqp_types"))
+	assert.IsIncreasing(t, types)
+}